@@ -0,0 +1,48 @@
+package authn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ecloudclub/zkit/httpx"
+)
+
+var _ httpx.TokenSource = (*TokenSource)(nil)
+
+func TestJWTHandler_TokenSource(t *testing.T) {
+	handler, err := New(&Config{
+		SecretKey: []byte("gE1cK7kD1pK5aV9jT6fA6nV4dQ7zO1cT"),
+		Timeout:   time.Second,
+		PayloadFunc: func(data interface{}) MapClaims {
+			return MapClaims{"name": data.(*User).Name}
+		},
+	})
+	require.NoError(t, err)
+
+	ts := handler.TokenSource(&User{Id: 1, Name: "frank"})
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		return handler.config.SecretKey, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "frank", parsed.Claims.(jwt.MapClaims)["name"])
+
+	// cached until it is about to expire
+	cached, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token, cached)
+
+	ts.mu.Lock()
+	ts.expire = time.Now().Add(-time.Millisecond)
+	ts.mu.Unlock()
+	time.Sleep(time.Second) // orig_iat has a resolution of a second
+	renewed, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, token, renewed)
+}
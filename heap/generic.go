@@ -0,0 +1,79 @@
+package heap
+
+// Heap 泛型二叉堆，堆顶是按 less 排序最小的元素，非并发安全
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// New 创建一个空堆，less(a, b) 为 true 时 a 更靠近堆顶，
+// 例如 func(a, b int) bool { return a > b } 为大顶堆
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// Len 返回堆中元素的数量
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push 推入元素，时间复杂度 O(log n)
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.up(len(h.items) - 1)
+}
+
+// Pop 弹出堆顶元素，堆为空时返回 false
+func (h *Heap[T]) Pop() (T, bool) {
+	var zero T
+	n := len(h.items) - 1
+	if n < 0 {
+		return zero, false
+	}
+	top := h.items[0]
+	h.items[0] = h.items[n]
+	h.items[n] = zero // 避免持有已弹出元素的引用
+	h.items = h.items[:n]
+	h.down(0)
+	return top, true
+}
+
+// Peek 获取堆顶元素但不弹出，堆为空时返回 false
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// up 上浮
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+// down 迭代式下沉
+func (h *Heap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		candidate := i
+		if left := 2*i + 1; left < n && h.less(h.items[left], h.items[candidate]) {
+			candidate = left
+		}
+		if right := 2*i + 2; right < n && h.less(h.items[right], h.items[candidate]) {
+			candidate = right
+		}
+		if candidate == i {
+			return
+		}
+		h.items[i], h.items[candidate] = h.items[candidate], h.items[i]
+		i = candidate
+	}
+}
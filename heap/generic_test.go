@@ -0,0 +1,57 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenericHeap(t *testing.T) {
+	testCases := []struct {
+		name string
+		less func(a, b int) bool
+		want []int
+	}{
+		{name: "小顶堆", less: func(a, b int) bool { return a < b }, want: []int{1, 2, 3, 4, 5}},
+		{name: "大顶堆", less: func(a, b int) bool { return a > b }, want: []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := New(tc.less)
+			_, ok := h.Peek()
+			assert.False(t, ok)
+			for _, num := range []int{3, 1, 5, 2, 4} {
+				h.Push(num)
+			}
+			top, ok := h.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, tc.want[0], top)
+			assert.Equal(t, 5, h.Len())
+
+			var got []int
+			for h.Len() > 0 {
+				num, _ := h.Pop()
+				got = append(got, num)
+			}
+			assert.Equal(t, tc.want, got)
+			_, ok = h.Pop()
+			assert.False(t, ok)
+		})
+	}
+
+	// 随机数据
+	h := New(func(a, b int) bool { return a < b })
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = rand.IntN(100)
+		h.Push(nums[i])
+	}
+	slices.Sort(nums)
+	for _, want := range nums {
+		got, _ := h.Pop()
+		assert.Equal(t, want, got)
+	}
+}
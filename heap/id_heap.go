@@ -0,0 +1,82 @@
+package heap
+
+// idEntry IDHeap 中保存的元素及其 ID
+type idEntry[K comparable, T any] struct {
+	id   K
+	item T
+}
+
+// IDHeap 以可比较的 ID 索引元素的堆，可在 O(log n) 时间内按 ID 取消元素，
+// 适用于时间轮、去重调度器等需要取消待处理项的场景，非并发安全
+type IDHeap[K comparable, T, P any] struct {
+	q       *PriorityQueue[idEntry[K, T], P]
+	handles map[K]*Handle[idEntry[K, T], P]
+}
+
+// NewIDHeap 创建一个空的 IDHeap，less(a, b) 为 true 时优先级为 a 的元素先出堆
+func NewIDHeap[K comparable, T, P any](less func(a, b P) bool) *IDHeap[K, T, P] {
+	return &IDHeap[K, T, P]{
+		q:       NewPriorityQueue[idEntry[K, T]](less),
+		handles: make(map[K]*Handle[idEntry[K, T], P]),
+	}
+}
+
+// Len 返回堆中元素的数量
+func (h *IDHeap[K, T, P]) Len() int {
+	return h.q.Len()
+}
+
+// PushID 推入 ID 为 id 的元素，id 已存在时替换其元素和优先级并返回 false
+func (h *IDHeap[K, T, P]) PushID(id K, item T, priority P) bool {
+	if handle, ok := h.handles[id]; ok {
+		handle.Value.item = item
+		h.q.Update(handle, priority)
+		return false
+	}
+	h.handles[id] = h.q.Push(idEntry[K, T]{id: id, item: item}, priority)
+	return true
+}
+
+// RemoveID 移除 ID 为 id 的元素，id 不存在时返回 false
+func (h *IDHeap[K, T, P]) RemoveID(id K) bool {
+	handle, ok := h.handles[id]
+	if !ok {
+		return false
+	}
+	delete(h.handles, id)
+	return h.q.Remove(handle)
+}
+
+// Contains 判断 ID 为 id 的元素是否在堆中
+func (h *IDHeap[K, T, P]) Contains(id K) bool {
+	_, ok := h.handles[id]
+	return ok
+}
+
+// Get 获取 ID 为 id 的元素及其优先级，id 不存在时返回 false
+func (h *IDHeap[K, T, P]) Get(id K) (T, P, bool) {
+	handle, ok := h.handles[id]
+	if !ok {
+		var (
+			item     T
+			priority P
+		)
+		return item, priority, false
+	}
+	return handle.Value.item, handle.Priority(), true
+}
+
+// Pop 弹出优先级最高的元素及其 ID，堆为空时返回 false
+func (h *IDHeap[K, T, P]) Pop() (K, T, P, bool) {
+	e, priority, ok := h.q.Pop()
+	if ok {
+		delete(h.handles, e.id)
+	}
+	return e.id, e.item, priority, ok
+}
+
+// Peek 获取优先级最高的元素及其 ID 但不弹出，堆为空时返回 false
+func (h *IDHeap[K, T, P]) Peek() (K, T, P, bool) {
+	e, priority, ok := h.q.Peek()
+	return e.id, e.item, priority, ok
+}
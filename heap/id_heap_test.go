@@ -0,0 +1,55 @@
+package heap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDHeap(t *testing.T) {
+	now := time.Now()
+	h := NewIDHeap[string, string](func(a, b time.Time) bool { return a.Before(b) })
+	_, _, _, ok := h.Peek()
+	assert.False(t, ok)
+
+	assert.True(t, h.PushID("a", "task a", now.Add(3*time.Second)))
+	assert.True(t, h.PushID("b", "task b", now.Add(time.Second)))
+	assert.True(t, h.PushID("c", "task c", now.Add(2*time.Second)))
+	assert.Equal(t, 3, h.Len())
+	assert.True(t, h.Contains("b"))
+
+	// 重复的 ID 替换元素和优先级
+	assert.False(t, h.PushID("a", "task a2", now))
+	assert.Equal(t, 3, h.Len())
+	item, deadline, ok := h.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "task a2", item)
+	assert.Equal(t, now, deadline)
+
+	id, item, _, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "a", id)
+	assert.Equal(t, "task a2", item)
+
+	// 取消
+	assert.True(t, h.RemoveID("b"))
+	assert.False(t, h.RemoveID("b"))
+	assert.False(t, h.Contains("b"))
+	_, _, ok = h.Get("b")
+	assert.False(t, ok)
+
+	var ids []string
+	for h.Len() > 0 {
+		id, _, _, _ := h.Pop()
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"a", "c"}, ids)
+	assert.False(t, h.Contains("a"))
+	_, _, _, ok = h.Pop()
+	assert.False(t, ok)
+
+	// 弹出后可以再次推入
+	assert.True(t, h.PushID("a", "task a3", now))
+	assert.True(t, h.Contains("a"))
+}
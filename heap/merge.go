@@ -0,0 +1,65 @@
+package heap
+
+import "iter"
+
+// mergeEntry 归并时堆中的元素，记录值来自哪个序列
+type mergeEntry[T any] struct {
+	value T
+	src   int
+}
+
+// MergeSorted 通过 K 路堆归并将多个已按 less 排序的序列合并为一个有序序列，
+// 值相等时先输出靠前序列中的元素，每次只从各序列拉取一个元素，适合合并按时间排序的日志或分片
+func MergeSorted[T any](less func(a, b T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		h := New(func(a, b mergeEntry[T]) bool {
+			if less(a.value, b.value) {
+				return true
+			}
+			return !less(b.value, a.value) && a.src < b.src
+		})
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+			if v, ok := next(); ok {
+				h.Push(mergeEntry[T]{value: v, src: i})
+			}
+		}
+
+		for {
+			e, ok := h.Pop()
+			if !ok {
+				return
+			}
+			if !yield(e.value) {
+				return
+			}
+			if v, ok := nexts[e.src](); ok {
+				h.Push(mergeEntry[T]{value: v, src: e.src})
+			}
+		}
+	}
+}
+
+// MergeSortedSlices 将多个已按 less 排序的切片合并为一个新的有序切片
+func MergeSortedSlices[T any](less func(a, b T) bool, slices ...[]T) []T {
+	n := 0
+	seqs := make([]iter.Seq[T], len(slices))
+	for i, s := range slices {
+		n += len(s)
+		seqs[i] = func(yield func(T) bool) {
+			for _, v := range s {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	res := make([]T, 0, n)
+	for v := range MergeSorted(less, seqs...) {
+		res = append(res, v)
+	}
+	return res
+}
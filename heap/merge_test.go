@@ -0,0 +1,56 @@
+package heap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	testCases := []struct {
+		name   string
+		slices [][]int
+		want   []int
+	}{
+		{name: "无序列", want: []int{}},
+		{name: "空序列", slices: [][]int{{}, nil}, want: []int{}},
+		{name: "单个序列", slices: [][]int{{1, 2, 3}}, want: []int{1, 2, 3}},
+		{name: "多个序列", slices: [][]int{{1, 4, 7}, {2, 5, 8}, {}, {0, 3, 6, 9, 10}}, want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{name: "重复元素", slices: [][]int{{1, 1, 3}, {1, 2, 3}}, want: []int{1, 1, 1, 2, 3, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, MergeSortedSlices(less, tc.slices...))
+		})
+	}
+}
+
+func TestMergeSorted_Stable(t *testing.T) {
+	type log struct {
+		ts    int
+		shard string
+	}
+	less := func(a, b log) bool { return a.ts < b.ts }
+	got := MergeSortedSlices(less,
+		[]log{{1, "a"}, {2, "a"}},
+		[]log{{1, "b"}, {2, "b"}},
+	)
+	assert.Equal(t, []log{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}, got)
+}
+
+func TestMergeSorted_Break(t *testing.T) {
+	var got []int
+	for v := range MergeSorted(func(a, b int) bool { return a > b },
+		slices.Values([]int{9, 5, 1}),
+		slices.Values([]int{8, 4}),
+	) {
+		if v < 5 {
+			break
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{9, 8, 5}, got)
+}
@@ -0,0 +1,164 @@
+package heap
+
+import "math/bits"
+
+// MinMax 最小最大堆，偶数层满足最小堆性质、奇数层满足最大堆性质，
+// 可在 O(log n) 时间内从两端弹出元素，适用于有界缓存和中位数维护等场景，非并发安全
+type MinMax[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewMinMax 创建一个空的最小最大堆，less 定义元素的大小关系
+func NewMinMax[T any](less func(a, b T) bool) *MinMax[T] {
+	return &MinMax[T]{less: less}
+}
+
+// Len 返回堆中元素的数量
+func (h *MinMax[T]) Len() int {
+	return len(h.items)
+}
+
+// Push 推入元素，时间复杂度 O(log n)
+func (h *MinMax[T]) Push(item T) {
+	h.items = append(h.items, item)
+	i := len(h.items) - 1
+	if i == 0 {
+		return
+	}
+	parent := (i - 1) / 2
+	if isMinLevel(i) {
+		if h.less(h.items[parent], h.items[i]) {
+			h.swap(i, parent)
+			h.bubbleUp(parent, h.greater)
+		} else {
+			h.bubbleUp(i, h.less)
+		}
+	} else {
+		if h.less(h.items[i], h.items[parent]) {
+			h.swap(i, parent)
+			h.bubbleUp(parent, h.less)
+		} else {
+			h.bubbleUp(i, h.greater)
+		}
+	}
+}
+
+// Min 获取最小的元素，堆为空时返回 false
+func (h *MinMax[T]) Min() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// Max 获取最大的元素，堆为空时返回 false
+func (h *MinMax[T]) Max() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[h.maxIndex()], true
+}
+
+// PopMin 弹出最小的元素，堆为空时返回 false
+func (h *MinMax[T]) PopMin() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.remove(0), true
+}
+
+// PopMax 弹出最大的元素，堆为空时返回 false
+func (h *MinMax[T]) PopMax() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.remove(h.maxIndex()), true
+}
+
+// maxIndex 最大的元素是根节点或它的某个子节点
+func (h *MinMax[T]) maxIndex() int {
+	switch len(h.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.less(h.items[1], h.items[2]) {
+		return 2
+	}
+	return 1
+}
+
+// remove 移除下标为 i 的元素，用最后一个元素填补后下沉
+func (h *MinMax[T]) remove(i int) T {
+	var zero T
+	n := len(h.items) - 1
+	item := h.items[i]
+	h.items[i] = h.items[n]
+	h.items[n] = zero
+	h.items = h.items[:n]
+	if i < n {
+		if isMinLevel(i) {
+			h.trickleDown(i, h.less)
+		} else {
+			h.trickleDown(i, h.greater)
+		}
+	}
+	return item
+}
+
+// bubbleUp 沿祖父节点上浮，before 为 less 时处理最小层，为 greater 时处理最大层
+func (h *MinMax[T]) bubbleUp(i int, before func(a, b T) bool) {
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !before(h.items[i], h.items[grandparent]) {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// trickleDown 在子节点和孙节点中寻找最靠前的元素下沉，before 的含义同 bubbleUp
+func (h *MinMax[T]) trickleDown(i int, before func(a, b T) bool) {
+	n := len(h.items)
+	for {
+		m := -1
+		first := 2*i + 1
+		// 子节点为 first、first+1，孙节点为 2*first+1 到 2*first+4
+		for _, j := range [...]int{first, first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if j < n && (m < 0 || before(h.items[j], h.items[m])) {
+				m = j
+			}
+		}
+		if m < 0 || !before(h.items[m], h.items[i]) {
+			return
+		}
+		h.swap(i, m)
+		if m <= first+1 {
+			return // 子节点位于另一种层，交换后无需继续
+		}
+		if parent := (m - 1) / 2; before(h.items[parent], h.items[m]) {
+			h.swap(m, parent)
+		}
+		i = m
+	}
+}
+
+func (h *MinMax[T]) greater(a, b T) bool {
+	return h.less(b, a)
+}
+
+func (h *MinMax[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+// isMinLevel 下标 i 是否位于最小层，根节点为第 0 层
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
@@ -0,0 +1,71 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMax(t *testing.T) {
+	h := NewMinMax(func(a, b int) bool { return a < b })
+	_, ok := h.Min()
+	assert.False(t, ok)
+	_, ok = h.Max()
+	assert.False(t, ok)
+	_, ok = h.PopMin()
+	assert.False(t, ok)
+	_, ok = h.PopMax()
+	assert.False(t, ok)
+
+	h.Push(5)
+	minVal, _ := h.Min()
+	maxVal, _ := h.Max()
+	assert.Equal(t, 5, minVal)
+	assert.Equal(t, 5, maxVal)
+
+	for _, num := range []int{3, 8, 1, 9, 2, 7} {
+		h.Push(num)
+	}
+	assert.Equal(t, 7, h.Len())
+	minVal, _ = h.PopMin()
+	maxVal, _ = h.PopMax()
+	assert.Equal(t, 1, minVal)
+	assert.Equal(t, 9, maxVal)
+	minVal, _ = h.Min()
+	maxVal, _ = h.Max()
+	assert.Equal(t, 2, minVal)
+	assert.Equal(t, 8, maxVal)
+}
+
+func TestMinMax_Random(t *testing.T) {
+	h := NewMinMax(func(a, b int) bool { return a < b })
+	var want []int
+	for i := 0; i < 2000; i++ {
+		switch op := rand.IntN(3); {
+		case op < 2 || len(want) == 0:
+			num := rand.IntN(500)
+			h.Push(num)
+			want = append(want, num)
+			slices.Sort(want)
+		case rand.IntN(2) == 0:
+			got, ok := h.PopMin()
+			assert.True(t, ok)
+			assert.Equal(t, want[0], got)
+			want = want[1:]
+		default:
+			got, ok := h.PopMax()
+			assert.True(t, ok)
+			assert.Equal(t, want[len(want)-1], got)
+			want = want[:len(want)-1]
+		}
+		assert.Equal(t, len(want), h.Len())
+		if len(want) > 0 {
+			minVal, _ := h.Min()
+			maxVal, _ := h.Max()
+			assert.Equal(t, want[0], minVal)
+			assert.Equal(t, want[len(want)-1], maxVal)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package heap
+
+// Handle 优先队列中元素的句柄，由 Push 返回，用于更新优先级或移除元素
+type Handle[T, P any] struct {
+	Value    T
+	priority P
+	index    int // 在堆中的下标，移除后为 -1
+}
+
+// Priority 返回元素当前的优先级
+func (e *Handle[T, P]) Priority() P {
+	return e.priority
+}
+
+// PriorityQueue 支持通过句柄更新优先级和移除元素的优先队列，非并发安全
+type PriorityQueue[T, P any] struct {
+	items []*Handle[T, P]
+	less  func(a, b P) bool
+}
+
+// NewPriorityQueue 创建一个空的优先队列，less(a, b) 为 true 时优先级为 a 的元素先出队
+func NewPriorityQueue[T, P any](less func(a, b P) bool) *PriorityQueue[T, P] {
+	return &PriorityQueue[T, P]{less: less}
+}
+
+// Len 返回队列中元素的数量
+func (q *PriorityQueue[T, P]) Len() int {
+	return len(q.items)
+}
+
+// Push 推入元素并返回其句柄，时间复杂度 O(log n)
+func (q *PriorityQueue[T, P]) Push(value T, priority P) *Handle[T, P] {
+	e := &Handle[T, P]{Value: value, priority: priority, index: len(q.items)}
+	q.items = append(q.items, e)
+	q.up(e.index)
+	return e
+}
+
+// Pop 弹出优先级最高的元素，队列为空时返回 false
+func (q *PriorityQueue[T, P]) Pop() (T, P, bool) {
+	if len(q.items) == 0 {
+		var (
+			value    T
+			priority P
+		)
+		return value, priority, false
+	}
+	e := q.items[0]
+	q.remove(0)
+	return e.Value, e.priority, true
+}
+
+// Peek 获取优先级最高的元素但不弹出，队列为空时返回 false
+func (q *PriorityQueue[T, P]) Peek() (T, P, bool) {
+	if len(q.items) == 0 {
+		var (
+			value    T
+			priority P
+		)
+		return value, priority, false
+	}
+	return q.items[0].Value, q.items[0].priority, true
+}
+
+// Update 更新元素的优先级，时间复杂度 O(log n)，元素已出队或不属于该队列时返回 false
+func (q *PriorityQueue[T, P]) Update(h *Handle[T, P], priority P) bool {
+	if !q.contains(h) {
+		return false
+	}
+	h.priority = priority
+	q.fix(h.index)
+	return true
+}
+
+// Remove 移除元素，时间复杂度 O(log n)，元素已出队或不属于该队列时返回 false
+func (q *PriorityQueue[T, P]) Remove(h *Handle[T, P]) bool {
+	if !q.contains(h) {
+		return false
+	}
+	q.remove(h.index)
+	return true
+}
+
+func (q *PriorityQueue[T, P]) contains(h *Handle[T, P]) bool {
+	return h != nil && h.index >= 0 && h.index < len(q.items) && q.items[h.index] == h
+}
+
+// remove 移除下标为 i 的元素，用最后一个元素填补后重新调整
+func (q *PriorityQueue[T, P]) remove(i int) {
+	n := len(q.items) - 1
+	e := q.items[i]
+	if i != n {
+		q.swap(i, n)
+	}
+	q.items[n] = nil
+	q.items = q.items[:n]
+	e.index = -1
+	if i != n {
+		q.fix(i)
+	}
+}
+
+// fix 在下标 i 的优先级变化后恢复堆序
+func (q *PriorityQueue[T, P]) fix(i int) {
+	if !q.down(i) {
+		q.up(i)
+	}
+}
+
+func (q *PriorityQueue[T, P]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+// up 上浮
+func (q *PriorityQueue[T, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i].priority, q.items[parent].priority) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down 迭代式下沉，返回是否发生了移动
+func (q *PriorityQueue[T, P]) down(i int) bool {
+	start, n := i, len(q.items)
+	for {
+		candidate := i
+		if left := 2*i + 1; left < n && q.less(q.items[left].priority, q.items[candidate].priority) {
+			candidate = left
+		}
+		if right := 2*i + 2; right < n && q.less(q.items[right].priority, q.items[candidate].priority) {
+			candidate = right
+		}
+		if candidate == i {
+			return i > start
+		}
+		q.swap(i, candidate)
+		i = candidate
+	}
+}
@@ -0,0 +1,86 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	q := NewPriorityQueue[string](func(a, b int) bool { return a < b })
+	_, _, ok := q.Peek()
+	assert.False(t, ok)
+
+	a := q.Push("a", 3)
+	b := q.Push("b", 1)
+	c := q.Push("c", 2)
+	d := q.Push("d", 4)
+	assert.Equal(t, 4, q.Len())
+
+	value, priority, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "b", value)
+	assert.Equal(t, 1, priority)
+
+	// 提高优先级
+	assert.True(t, q.Update(d, 0))
+	assert.Equal(t, 0, d.Priority())
+	// 降低优先级
+	assert.True(t, q.Update(b, 5))
+	assert.True(t, q.Remove(c))
+	assert.False(t, q.Remove(c))
+	assert.False(t, q.Update(c, 1))
+
+	var got []string
+	for q.Len() > 0 {
+		value, _, _ := q.Pop()
+		got = append(got, value)
+	}
+	assert.Equal(t, []string{"d", "a", "b"}, got)
+	assert.False(t, q.Remove(a))
+	_, _, ok = q.Pop()
+	assert.False(t, ok)
+
+	// 不属于该队列的句柄
+	other := NewPriorityQueue[string](func(a, b int) bool { return a < b })
+	e := other.Push("e", 1)
+	q.Push("f", 1)
+	assert.False(t, q.Remove(e))
+	assert.False(t, q.Remove(nil))
+}
+
+func TestPriorityQueue_Random(t *testing.T) {
+	q := NewPriorityQueue[int](func(a, b int) bool { return a < b })
+	priorities := make(map[int]int)
+	handles := make(map[int]*Handle[int, int])
+	for i := 0; i < 500; i++ {
+		priorities[i] = rand.IntN(1000)
+		handles[i] = q.Push(i, priorities[i])
+	}
+	for i := 0; i < 500; i++ {
+		id := rand.IntN(500)
+		if rand.IntN(2) == 0 {
+			priority := rand.IntN(1000)
+			if q.Update(handles[id], priority) {
+				priorities[id] = priority
+			}
+		} else if q.Remove(handles[id]) {
+			delete(priorities, id)
+		}
+	}
+
+	want := make([]int, 0, len(priorities))
+	for _, p := range priorities {
+		want = append(want, p)
+	}
+	sort.Ints(want)
+	got := make([]int, 0, q.Len())
+	for q.Len() > 0 {
+		value, priority, _ := q.Pop()
+		assert.Equal(t, priorities[value], priority)
+		got = append(got, priority)
+	}
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,60 @@
+package heap
+
+// HeapSort 按 less 对 nums 进行原地升序堆排序，时间复杂度 O(n log n)，不稳定
+func HeapSort[T any](nums []T, less func(a, b T) bool) {
+	n := len(nums)
+	heapifyFunc(nums, less)
+	for end := n - 1; end > 0; end-- {
+		// 将当前最大的元素放到末尾
+		nums[0], nums[end] = nums[end], nums[0]
+		siftDownFunc(nums, 0, end, less)
+	}
+}
+
+// PartialSort 按 less 重新排列 nums，使前 k 个元素为最小的 k 个元素且升序排列，其余元素顺序不定，
+// 时间复杂度 O(n log k)，适用于 Top K 等只需部分有序的场景，k 大于 len(nums) 时等价于 HeapSort
+func PartialSort[T any](nums []T, k int, less func(a, b T) bool) {
+	k = min(k, len(nums))
+	if k <= 0 {
+		return
+	}
+	// 前 k 个元素构成大顶堆，堆顶为其中最大的元素
+	top := nums[:k]
+	heapifyFunc(top, less)
+	for i := k; i < len(nums); i++ {
+		if less(nums[i], top[0]) {
+			top[0], nums[i] = nums[i], top[0]
+			siftDownFunc(top, 0, k, less)
+		}
+	}
+	for end := k - 1; end > 0; end-- {
+		top[0], top[end] = top[end], top[0]
+		siftDownFunc(top, 0, end, less)
+	}
+}
+
+// heapifyFunc 按 less 将 nums 堆化为大顶堆
+func heapifyFunc[T any](nums []T, less func(a, b T) bool) {
+	n := len(nums)
+	for i := n/2 - 1; i >= 0; i-- {
+		siftDownFunc(nums, i, n, less)
+	}
+}
+
+// siftDownFunc 大顶堆的迭代式下沉
+func siftDownFunc[T any](nums []T, i, n int, less func(a, b T) bool) {
+	for {
+		candidate := i
+		if left := 2*i + 1; left < n && less(nums[candidate], nums[left]) {
+			candidate = left
+		}
+		if right := 2*i + 2; right < n && less(nums[candidate], nums[right]) {
+			candidate = right
+		}
+		if candidate == i {
+			return
+		}
+		nums[i], nums[candidate] = nums[candidate], nums[i]
+		i = candidate
+	}
+}
@@ -0,0 +1,74 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeapSort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	testCases := []struct {
+		name string
+		nums []int
+		want []int
+	}{
+		{name: "空切片", nums: []int{}, want: []int{}},
+		{name: "单个元素", nums: []int{1}, want: []int{1}},
+		{name: "重复元素", nums: []int{3, 1, 2, 3, 1}, want: []int{1, 1, 2, 3, 3}},
+		{name: "逆序", nums: []int{5, 4, 3, 2, 1}, want: []int{1, 2, 3, 4, 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			HeapSort(tc.nums, less)
+			assert.Equal(t, tc.want, tc.nums)
+		})
+	}
+
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = rand.IntN(100)
+	}
+	want := slices.Clone(nums)
+	slices.Sort(want)
+	HeapSort(nums, less)
+	assert.Equal(t, want, nums)
+}
+
+func TestPartialSort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	testCases := []struct {
+		name string
+		nums []int
+		k    int
+		want []int
+	}{
+		{name: "k 为 0", nums: []int{3, 1, 2}, k: 0, want: []int{}},
+		{name: "前 2 个", nums: []int{3, 2, 1, 5, 6, 4}, k: 2, want: []int{1, 2}},
+		{name: "k 等于长度", nums: []int{3, 2, 1}, k: 3, want: []int{1, 2, 3}},
+		{name: "k 超过长度", nums: []int{3, 2, 1}, k: 5, want: []int{1, 2, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			all := slices.Clone(tc.nums)
+			PartialSort(tc.nums, tc.k, less)
+			assert.Equal(t, tc.want, tc.nums[:len(tc.want)])
+			// 只重新排列，不丢失元素
+			assert.ElementsMatch(t, all, tc.nums)
+		})
+	}
+
+	// Top K 大
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = rand.IntN(1000)
+	}
+	want := slices.Clone(nums)
+	slices.SortFunc(want, func(a, b int) int { return b - a })
+	PartialSort(nums, 10, func(a, b int) bool { return a > b })
+	assert.Equal(t, want[:10], nums[:10])
+}
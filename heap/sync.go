@@ -0,0 +1,75 @@
+package heap
+
+import (
+	"context"
+	"sync"
+)
+
+// Sync 并发安全的堆，可在生产者和消费者之间共享
+type Sync[T any] struct {
+	mu sync.Mutex
+	h  *Heap[T]
+	// wait 在有消费者等待时创建，推入元素时关闭以唤醒所有等待者
+	wait chan struct{}
+}
+
+// NewSync 创建一个并发安全的空堆，less 的含义同 New
+func NewSync[T any](less func(a, b T) bool) *Sync[T] {
+	return &Sync[T]{h: New(less)}
+}
+
+// Len 返回堆中元素的数量
+func (s *Sync[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Len()
+}
+
+// Push 推入元素并唤醒等待的消费者
+func (s *Sync[T]) Push(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.Push(item)
+	if s.wait != nil {
+		close(s.wait)
+		s.wait = nil
+	}
+}
+
+// Pop 弹出堆顶元素，堆为空时立即返回 false
+func (s *Sync[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Pop()
+}
+
+// Peek 获取堆顶元素但不弹出，堆为空时返回 false
+func (s *Sync[T]) Peek() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Peek()
+}
+
+// PopWait 弹出堆顶元素，堆为空时阻塞直到有元素推入或 ctx 结束
+func (s *Sync[T]) PopWait(ctx context.Context) (T, error) {
+	for {
+		s.mu.Lock()
+		if item, ok := s.h.Pop(); ok {
+			s.mu.Unlock()
+			return item, nil
+		}
+		if s.wait == nil {
+			s.wait = make(chan struct{})
+		}
+		wait := s.wait
+		s.mu.Unlock()
+
+		select {
+		case <-wait:
+			// 可能被其他消费者抢先弹出，重新检查
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package heap
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	s := NewSync(func(a, b int) bool { return a < b })
+	s.Push(2)
+	s.Push(1)
+	assert.Equal(t, 2, s.Len())
+	top, ok := s.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, top)
+
+	num, err := s.PopWait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, num)
+	num, ok = s.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 2, num)
+
+	// 堆为空时阻塞到 ctx 结束
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = s.PopWait(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestSync_ProducerConsumer(t *testing.T) {
+	s := NewSync(func(a, b int) bool { return a < b })
+	const producers, consumers, perProducer = 4, 4, 250
+
+	var (
+		mu  sync.Mutex
+		got = make(map[int]bool)
+		wg  sync.WaitGroup
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < consumers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				num, err := s.PopWait(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				got[num] = true
+				if len(got) == producers*perProducer {
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < producers; i++ {
+		go func(i int) {
+			for j := 0; j < perProducer; j++ {
+				s.Push(i*perProducer + j)
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("消费者未能取完所有元素")
+	}
+	assert.Len(t, got, producers*perProducer)
+	assert.Equal(t, 0, s.Len())
+}
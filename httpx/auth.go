@@ -0,0 +1,54 @@
+package httpx
+
+import "context"
+
+// TokenSource provides the token to authenticate a request with, see Request.TokenSource.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is an adapter to allow the use of ordinary functions as TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// BasicAuth sets the Authorization header with the username and password.
+func (r *Request) BasicAuth(username, password string) *Request {
+	if r.err != nil {
+		return r
+	}
+	r.req.SetBasicAuth(username, password)
+	return r
+}
+
+// BearerToken sets the Authorization header with the bearer token.
+func (r *Request) BearerToken(token string) *Request {
+	if r.err != nil {
+		return r
+	}
+	r.req.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+// TokenSource sets a bearer token obtained from ts when the request is sent,
+// so that a token which expires can be refreshed by ts.
+// Do returns the error of ts if it fails.
+func (r *Request) TokenSource(ts TokenSource) *Request {
+	r.tokenSource = ts
+	return r
+}
+
+// authorize sets the bearer token from the token source if any.
+func (r *Request) authorize() error {
+	if r.tokenSource == nil {
+		return nil
+	}
+	token, err := r.tokenSource.Token(r.req.Context())
+	if err != nil {
+		return err
+	}
+	r.req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
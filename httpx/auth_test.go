@@ -0,0 +1,45 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_BasicAuth(t *testing.T) {
+	req := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		BasicAuth("user", "pass")
+	user, pass, ok := req.req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", user)
+	assert.Equal(t, "pass", pass)
+}
+
+func TestRequest_BearerToken(t *testing.T) {
+	req := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		BearerToken("token")
+	assert.Equal(t, "Bearer token", req.req.Header.Get("Authorization"))
+}
+
+func TestRequest_TokenSource(t *testing.T) {
+	cnt := 0
+	req := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		TokenSource(TokenSourceFunc(func(ctx context.Context) (string, error) {
+			cnt++
+			return "token", nil
+		}))
+	// called at Do time
+	assert.Equal(t, 0, cnt)
+	assert.NoError(t, req.authorize())
+	assert.Equal(t, 1, cnt)
+	assert.Equal(t, "Bearer token", req.req.Header.Get("Authorization"))
+
+	resp := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		TokenSource(TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "", errors.New("mock error")
+		})).Do()
+	assert.Equal(t, errors.New("mock error"), resp.err)
+}
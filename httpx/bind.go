@@ -0,0 +1,174 @@
+package httpx
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/ecloudclub/zkit/option"
+	"github.com/ecloudclub/zkit/reflectx"
+)
+
+// defaultMaxBodySize is the default maximum size of the body accepted by Bind.
+const defaultMaxBodySize = 1 << 20
+
+// Validator is implemented by values that validate themselves after being bound.
+type Validator interface {
+	Validate() error
+}
+
+type binder struct {
+	maxBodySize int64
+	validators  []func(v any) error
+}
+
+type BindOption = option.Option[binder]
+
+// WithMaxBodySize limits the size of the body, 1MB by default.
+func WithMaxBodySize(n int64) BindOption {
+	return func(b *binder) {
+		b.maxBodySize = n
+	}
+}
+
+// WithValidation adds a validation hook which runs after v is bound,
+// e.g. to use a third-party validator.
+func WithValidation(fn func(v any) error) BindOption {
+	return func(b *binder) {
+		b.validators = append(b.validators, fn)
+	}
+}
+
+// Bind decodes the request into v, which must be a pointer to a struct.
+// Fields tagged with `query:"name"` are set from the query string. The body is decoded according to
+// its Content-Type: JSON into v, and forms into the fields tagged with `form:"name"`.
+// Then v is validated by the hooks and its Validate method if it implements Validator.
+// The errors are *StatusError with 400, 413 or 415, so that they can be written by WriteError directly.
+func Bind(r *http.Request, v any, opts ...BindOption) error {
+	b := &binder{maxBodySize: defaultMaxBodySize}
+	option.Apply(b, opts...)
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("zkit: Bind 的参数必须是结构体指针")
+	}
+
+	if err := bindValues(rv.Elem(), "query", r.URL.Query()); err != nil {
+		return err
+	}
+	if err := b.bindBody(r, v, rv.Elem()); err != nil {
+		return err
+	}
+
+	for _, validate := range b.validators {
+		if err := validate(v); err != nil {
+			return badRequest(err)
+		}
+	}
+	if val, ok := v.(Validator); ok {
+		if err := val.Validate(); err != nil {
+			return badRequest(err)
+		}
+	}
+	return nil
+}
+
+func (b *binder) bindBody(r *http.Request, v any, rv reflect.Value) error {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return nil
+	}
+	if r.ContentLength > b.maxBodySize {
+		return NewStatusError(http.StatusRequestEntityTooLarge, "")
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, b.maxBodySize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var err error
+	switch mediaType {
+	case "application/json":
+		err = json.NewDecoder(r.Body).Decode(v)
+		if errors.Is(err, io.EOF) {
+			// The body of unknown length may be empty
+			err = nil
+		}
+	case "application/x-www-form-urlencoded":
+		if err = r.ParseForm(); err == nil {
+			err = bindValues(rv, "form", r.PostForm)
+		}
+	case "multipart/form-data":
+		if err = r.ParseMultipartForm(b.maxBodySize); err == nil {
+			err = bindValues(rv, "form", r.MultipartForm.Value)
+		}
+	default:
+		return NewStatusError(http.StatusUnsupportedMediaType, "")
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &maxBytesErr):
+		return &StatusError{Code: http.StatusRequestEntityTooLarge, Message: http.StatusText(http.StatusRequestEntityTooLarge), Err: err}
+	default:
+		return badRequest(err)
+	}
+}
+
+func badRequest(err error) error {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return err
+	}
+	return &StatusError{Code: http.StatusBadRequest, Message: err.Error(), Err: err}
+}
+
+// bindValues sets the fields of rv tagged with tag, including the ones of embedded structs.
+func bindValues(rv reflect.Value, tag string, values url.Values) error {
+	for _, field := range reflectx.StructFields(rv.Type(), tag) {
+		if !field.IsExported() {
+			continue
+		}
+		fv := rv.FieldByIndex(field.Index)
+		name := field.Tag.Name
+		if name == "" || field.Tag.Ignored {
+			if field.Anonymous && fv.Kind() == reflect.Struct {
+				if err := bindValues(fv, tag, values); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		vals, ok := values[name]
+		if !ok || len(vals) == 0 {
+			continue
+		}
+		if err := setField(fv, vals); err != nil {
+			return badRequest(fmt.Errorf("zkit: 参数 %s 无效: %w", name, err))
+		}
+	}
+	return nil
+}
+
+func setField(fv reflect.Value, vals []string) error {
+	if fv.Kind() == reflect.Slice && !implementsTextUnmarshaler(fv) {
+		slice := reflect.MakeSlice(fv.Type(), len(vals), len(vals))
+		for i, s := range vals {
+			if err := reflectx.SetValue(slice.Index(i), s); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+	return reflectx.SetValue(fv, vals[0])
+}
+
+func implementsTextUnmarshaler(fv reflect.Value) bool {
+	return fv.Addr().Type().Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
@@ -0,0 +1,143 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Page struct {
+	Offset int `query:"offset"`
+	Limit  int `query:"limit"`
+}
+
+type SearchReq struct {
+	Page
+	Keyword  string        `query:"keyword" form:"keyword" json:"keyword"`
+	Tags     []string      `query:"tag" form:"tag" json:"tags"`
+	Deadline *time.Time    `query:"deadline"`
+	Timeout  time.Duration `form:"timeout"`
+}
+
+func (s *SearchReq) Validate() error {
+	if s.Limit > 100 {
+		return errors.New("limit 不能超过 100")
+	}
+	return nil
+}
+
+func TestBind(t *testing.T) {
+	deadline := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		opts        []BindOption
+		want        SearchReq
+		wantCode    int
+	}{
+		{
+			name:   "query",
+			target: "/?offset=10&limit=20&keyword=go&tag=a&tag=b&deadline=2025-01-01T00:00:00Z",
+			want: SearchReq{
+				Page:     Page{Offset: 10, Limit: 20},
+				Keyword:  "go",
+				Tags:     []string{"a", "b"},
+				Deadline: &deadline,
+			},
+		},
+		{
+			name:        "json",
+			target:      "/?limit=20",
+			contentType: "application/json",
+			body:        `{"keyword":"go","tags":["a"]}`,
+			want: SearchReq{
+				Page:    Page{Limit: 20},
+				Keyword: "go",
+				Tags:    []string{"a"},
+			},
+		},
+		{
+			name:        "form",
+			target:      "/",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "keyword=go&tag=a&timeout=1",
+			want: SearchReq{
+				Keyword: "go",
+				Tags:    []string{"a"},
+				Timeout: 1,
+			},
+		},
+		{
+			name:     "invalid query",
+			target:   "/?offset=abc",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid json",
+			target:      "/",
+			contentType: "application/json",
+			body:        `{"keyword":`,
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "unsupported content type",
+			target:      "/",
+			contentType: "text/plain",
+			body:        "go",
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "body too large",
+			target:      "/",
+			contentType: "application/json",
+			body:        `{"keyword":"golang"}`,
+			opts:        []BindOption{WithMaxBodySize(10)},
+			wantCode:    http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "validate",
+			target:   "/?limit=200",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "validation hook",
+			target: "/?keyword=go",
+			opts: []BindOption{WithValidation(func(v any) error {
+				return NewStatusError(http.StatusUnprocessableEntity, "")
+			})},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.target, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			var got SearchReq
+			err := Bind(req, &got, tc.opts...)
+			if tc.wantCode != 0 {
+				var se *StatusError
+				require.ErrorAs(t, err, &se)
+				assert.Equal(t, tc.wantCode, se.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBind_NotStructPointer(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	var s string
+	assert.Error(t, Bind(req, &s))
+	assert.Error(t, Bind(req, SearchReq{}))
+}
@@ -0,0 +1,61 @@
+package httpx
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ecloudclub/zkit/iox"
+)
+
+// defaultMaxResponseSize is the default maximum size of the body read by Response.Bytes.
+const defaultMaxResponseSize = 10 << 20
+
+// MaxResponseSize limits the size of the body read by Response.Bytes, 10MB by default.
+func (r *Request) MaxResponseSize(n int64) *Request {
+	r.maxResponseSize = n
+	return r
+}
+
+// Bytes reads the whole body and closes it. The body is kept in memory,
+// so Bytes, String and the decoding methods like JSONReceive can be called again.
+// It returns an *iox.LimitError if the body exceeds the limit set by Request.MaxResponseSize,
+// errors.Is(err, iox.ErrLimitExceeded) reports true for it.
+func (r *Response) Bytes() ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.buffered {
+		return r.body, nil
+	}
+
+	limit := r.maxBodySize
+	if limit <= 0 {
+		limit = defaultMaxResponseSize
+	}
+	body, _, err := iox.ReadAll(r.Body, limit)
+	_ = r.Body.Close()
+	if err != nil {
+		// The body has been consumed, so it cannot be read any more
+		r.err = err
+		return nil, err
+	}
+
+	r.body = body
+	r.buffered = true
+	r.Body = io.NopCloser(bytes.NewReader(r.body))
+	return r.body, nil
+}
+
+// String returns the body as a string, see Bytes.
+func (r *Response) String() (string, error) {
+	data, err := r.Bytes()
+	return string(data), err
+}
+
+// reader returns a reader of the body, which starts over each time once the body is buffered.
+func (r *Response) reader() io.Reader {
+	if r.buffered {
+		return bytes.NewReader(r.body)
+	}
+	return r.Body
+}
@@ -0,0 +1,41 @@
+package httpx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ecloudclub/zkit/iox"
+)
+
+func TestResponse_Bytes(t *testing.T) {
+	resp := newBodyResponse(`{"Name":"Tom"}`)
+	data, err := resp.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, `{"Name":"Tom"}`, string(data))
+
+	// the buffered body can be read again
+	s, err := resp.String()
+	require.NoError(t, err)
+	assert.Equal(t, `{"Name":"Tom"}`, s)
+	for i := 0; i < 2; i++ {
+		var u User
+		require.NoError(t, resp.JSONReceive(&u))
+		assert.Equal(t, User{Name: "Tom"}, u)
+	}
+}
+
+func TestResponse_BytesTooLarge(t *testing.T) {
+	resp := newBodyResponse(`{"Name":"Tom"}`)
+	resp.maxBodySize = 5
+	_, err := resp.Bytes()
+	assert.Equal(t, &iox.LimitError{Limit: 5}, err)
+	assert.ErrorIs(t, resp.JSONReceive(&User{}), iox.ErrLimitExceeded)
+
+	resp = newBodyResponse("hello")
+	resp.maxBodySize = 5
+	s, err := resp.String()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", s)
+}
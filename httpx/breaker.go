@@ -0,0 +1,232 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// ErrCircuitOpen is returned without sending the request when the circuit breaker of the host is open.
+var ErrCircuitOpen = errors.New("zkit: 熔断器已打开")
+
+// CircuitState is the state of the circuit breaker of a host.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through and counts their failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests with ErrCircuitOpen until the open timeout elapses.
+	CircuitOpen
+	// CircuitHalfOpen lets one probe request through,
+	// the circuit closes if it succeeds and opens again otherwise.
+	// If the probe is canceled by the caller, the circuit stays half-open and lets another probe through.
+	CircuitHalfOpen
+)
+
+// CircuitBreaker keeps a circuit breaker for every host, see Middleware.
+type CircuitBreaker struct {
+	failureRate float64
+	minRequests int
+	window      time.Duration
+	openTimeout time.Duration
+	isFailure   func(resp *http.Response, err error) bool
+
+	mu    sync.Mutex
+	hosts map[string]*breaker
+}
+
+// WithFailureRate sets the ratio of failed requests in a window above which the circuit opens.
+func WithFailureRate(rate float64) option.Option[CircuitBreaker] {
+	return func(cb *CircuitBreaker) {
+		cb.failureRate = rate
+	}
+}
+
+// WithMinRequests sets the number of requests in a window below which the circuit never opens.
+func WithMinRequests(n int) option.Option[CircuitBreaker] {
+	return func(cb *CircuitBreaker) {
+		cb.minRequests = n
+	}
+}
+
+// WithWindow sets the interval after which the counts of a closed circuit are reset.
+func WithWindow(d time.Duration) option.Option[CircuitBreaker] {
+	return func(cb *CircuitBreaker) {
+		cb.window = d
+	}
+}
+
+// WithOpenTimeout sets how long the circuit stays open before letting a probe request through.
+func WithOpenTimeout(d time.Duration) option.Option[CircuitBreaker] {
+	return func(cb *CircuitBreaker) {
+		cb.openTimeout = d
+	}
+}
+
+// WithFailureIf sets the predicate deciding whether a request has failed.
+// By default, errors other than the cancellation of the request and 5xx responses are failures.
+func WithFailureIf(isFailure func(resp *http.Response, err error) bool) option.Option[CircuitBreaker] {
+	return func(cb *CircuitBreaker) {
+		cb.isFailure = isFailure
+	}
+}
+
+// NewCircuitBreaker returns a CircuitBreaker which by default opens
+// when half of at least 10 requests fail within 10 seconds, and stays open for 5 seconds.
+func NewCircuitBreaker(opts ...option.Option[CircuitBreaker]) *CircuitBreaker {
+	cb := &CircuitBreaker{
+		failureRate: 0.5,
+		minRequests: 10,
+		window:      10 * time.Second,
+		openTimeout: 5 * time.Second,
+		isFailure: func(resp *http.Response, err error) bool {
+			if err != nil {
+				return !errors.Is(err, context.Canceled)
+			}
+			return resp.StatusCode >= http.StatusInternalServerError
+		},
+		hosts: make(map[string]*breaker),
+	}
+	option.Apply(cb, opts...)
+	return cb
+}
+
+// State returns the state of the circuit breaker of host.
+func (cb *CircuitBreaker) State(host string) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	b, ok := cb.hosts[host]
+	if !ok {
+		return CircuitClosed
+	}
+	return b.currentState(cb, time.Now())
+}
+
+// Middleware returns a middleware guarding every host with its own circuit breaker.
+func (cb *CircuitBreaker) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			host := req.URL.Host
+			gen, ok := cb.allow(host)
+			if !ok {
+				return nil, ErrCircuitOpen
+			}
+			resp, err := next.Do(req)
+			canceled := err != nil && errors.Is(req.Context().Err(), context.Canceled)
+			cb.record(host, gen, cb.isFailure(resp, err), canceled)
+			return resp, err
+		})
+	}
+}
+
+// allow reports whether a request to host can be sent,
+// together with the generation of the breaker the request belongs to.
+func (cb *CircuitBreaker) allow(host string) (uint64, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	b, ok := cb.hosts[host]
+	if !ok {
+		b = &breaker{windowStart: time.Now()}
+		cb.hosts[host] = b
+	}
+	switch b.currentState(cb, time.Now()) {
+	case CircuitOpen:
+		return 0, false
+	case CircuitHalfOpen:
+		if b.probing {
+			return 0, false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		// Only the probe belongs to the new generation
+		b.generation++
+		return b.generation, true
+	default:
+		return b.generation, true
+	}
+}
+
+// record counts the result of a request of generation gen,
+// canceled reports whether the request was canceled by the caller, which says nothing about a probe.
+func (cb *CircuitBreaker) record(host string, gen uint64, failed, canceled bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	b := cb.hosts[host]
+	// Requests sent before the circuit opened, or before the probe was let through, don't count
+	if gen != b.generation {
+		return
+	}
+	now := time.Now()
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+		if canceled {
+			return
+		}
+		if failed {
+			b.open(now)
+		} else {
+			b.generation++
+			b.reset(CircuitClosed, now)
+		}
+		return
+	}
+
+	if b.currentState(cb, now) != CircuitClosed {
+		return
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+	if b.requests >= cb.minRequests && float64(b.failures)/float64(b.requests) >= cb.failureRate {
+		b.open(now)
+	}
+}
+
+// breaker is the circuit breaker of a host, guarded by CircuitBreaker.mu.
+type breaker struct {
+	state       CircuitState
+	requests    int
+	failures    int
+	windowStart time.Time
+	openedAt    time.Time
+	probing     bool
+	// generation changes whenever the circuit opens, closes or lets a probe through,
+	// so that only the requests sent since then are counted
+	generation uint64
+}
+
+// currentState moves an open circuit to half-open once the open timeout elapses,
+// and resets the counts of a closed circuit at the end of a window.
+func (b *breaker) currentState(cb *CircuitBreaker, now time.Time) CircuitState {
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) >= cb.openTimeout {
+			return CircuitHalfOpen
+		}
+	case CircuitClosed:
+		if now.Sub(b.windowStart) >= cb.window {
+			b.reset(CircuitClosed, now)
+		}
+	}
+	return b.state
+}
+
+func (b *breaker) open(now time.Time) {
+	b.generation++
+	b.reset(CircuitOpen, now)
+	b.openedAt = now
+}
+
+func (b *breaker) reset(state CircuitState, now time.Time) {
+	b.state = state
+	b.requests = 0
+	b.failures = 0
+	b.windowStart = now
+}
@@ -0,0 +1,101 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	cb := NewCircuitBreaker(WithMinRequests(2), WithFailureRate(0.5), WithOpenTimeout(50*time.Millisecond))
+	status := http.StatusServiceUnavailable
+	do := func() *Response {
+		return NewRequest(context.Background(), http.MethodGet, "http://localhost/users").
+			Use(cb.Middleware(), func(next Doer) Doer {
+				return stubDoer(status, "")(next)
+			}).
+			Do()
+	}
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, do().err)
+	}
+	assert.Equal(t, CircuitOpen, cb.State("localhost"))
+	assert.Equal(t, ErrCircuitOpen, do().err)
+	// other hosts are not affected
+	assert.Equal(t, CircuitClosed, cb.State("example.com"))
+
+	// the probe fails and the circuit opens again
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, CircuitHalfOpen, cb.State("localhost"))
+	assert.NoError(t, do().err)
+	assert.Equal(t, CircuitOpen, cb.State("localhost"))
+
+	// the probe succeeds and the circuit closes
+	time.Sleep(50 * time.Millisecond)
+	status = http.StatusOK
+	assert.NoError(t, do().err)
+	assert.Equal(t, CircuitClosed, cb.State("localhost"))
+	assert.NoError(t, do().err)
+}
+
+func TestCircuitBreaker_StaleRequest(t *testing.T) {
+	cb := NewCircuitBreaker(WithMinRequests(1), WithOpenTimeout(10*time.Millisecond))
+	host := "localhost"
+
+	// a slow request is sent while the circuit is closed
+	slow, ok := cb.allow(host)
+	assert.True(t, ok)
+	fast, ok := cb.allow(host)
+	assert.True(t, ok)
+	cb.record(host, fast, true, false)
+	assert.Equal(t, CircuitOpen, cb.State(host))
+
+	time.Sleep(10 * time.Millisecond)
+	probe, ok := cb.allow(host)
+	assert.True(t, ok)
+	assert.Equal(t, CircuitHalfOpen, cb.State(host))
+
+	// the slow request completes during the probe, it must not decide the state
+	cb.record(host, slow, false, false)
+	assert.Equal(t, CircuitHalfOpen, cb.State(host))
+	_, ok = cb.allow(host)
+	assert.False(t, ok)
+
+	cb.record(host, probe, false, false)
+	assert.Equal(t, CircuitClosed, cb.State(host))
+}
+
+func TestCircuitBreaker_CanceledProbe(t *testing.T) {
+	cb := NewCircuitBreaker(WithMinRequests(1), WithOpenTimeout(10*time.Millisecond))
+	host := "localhost"
+	ctx, cancel := context.WithCancel(context.Background())
+	do := func(ctx context.Context, status int) *Response {
+		return NewRequest(ctx, http.MethodGet, "http://localhost/users").
+			Use(cb.Middleware(), func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					if err := req.Context().Err(); err != nil {
+						return nil, err
+					}
+					return stubDoer(status, "")(next).Do(req)
+				})
+			}).
+			Do()
+	}
+
+	assert.NoError(t, do(ctx, http.StatusServiceUnavailable).err)
+	assert.Equal(t, CircuitOpen, cb.State(host))
+
+	// the probe is canceled by the caller, neither closing nor opening the circuit
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, do(ctx, http.StatusOK).err, context.Canceled)
+	assert.Equal(t, CircuitHalfOpen, cb.State(host))
+
+	// another probe is let through
+	assert.NoError(t, do(context.Background(), http.StatusOK).err)
+	assert.Equal(t, CircuitClosed, cb.State(host))
+}
@@ -0,0 +1,238 @@
+package httpx
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a response stored by the cache middleware.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Expires is when the response becomes stale and has to be revalidated
+	Expires time.Time
+	// Vary holds the values of the request headers named by the Vary header of the response,
+	// the response is only served to requests with the same values
+	Vary http.Header
+}
+
+// CacheStore stores the cached responses by key, it must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+	Delete(key string)
+}
+
+// MemoryCache is a CacheStore keeping the responses in memory without eviction.
+type MemoryCache struct {
+	mu        sync.RWMutex
+	responses map[string]*CachedResponse
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{responses: make(map[string]*CachedResponse)}
+}
+
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	resp, ok := c.responses[key]
+	return resp, ok
+}
+
+func (c *MemoryCache) Set(key string, resp *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[key] = resp
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.responses, key)
+}
+
+// Cache returns a middleware caching the responses of GET requests in store.
+// Fresh responses are served without sending the request according to Cache-Control: max-age and Expires,
+// and stale ones are revalidated with If-None-Match and If-Modified-Since,
+// a 304 response being replaced by the cached one. Responses with Cache-Control: no-store are not cached.
+// A cached response is only served to requests having the same values for the headers named by its Vary header,
+// and requests with an Authorization header bypass the cache, so that no response is served to another user.
+// Only the body of a cacheable response is read into memory.
+func Cache(store CacheStore) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet || hasDirective(req.Header, "no-store") || req.Header.Get("Authorization") != "" {
+				return next.Do(req)
+			}
+
+			key := req.URL.String()
+			cached, ok := store.Get(key)
+			if ok && !cached.matches(req) {
+				cached, ok = nil, false
+			}
+			if ok && !hasDirective(req.Header, "no-cache") && time.Now().Before(cached.Expires) {
+				return cached.response(req), nil
+			}
+			if ok {
+				req = req.Clone(req.Context())
+				if etag := cached.Header.Get("ETag"); etag != "" {
+					req.Header.Set("If-None-Match", etag)
+				}
+				if lm := cached.Header.Get("Last-Modified"); lm != "" {
+					req.Header.Set("If-Modified-Since", lm)
+				}
+			}
+
+			resp, err := next.Do(req)
+			if err != nil {
+				return resp, err
+			}
+			if ok && resp.StatusCode == http.StatusNotModified {
+				_ = resp.Body.Close()
+				// The 304 response carries the updated headers,
+				// update a copy as the cached one may be in use
+				updated := *cached
+				updated.Header = cached.Header.Clone()
+				for k, vals := range resp.Header {
+					updated.Header[k] = vals
+				}
+				updated.Expires = expires(updated.Header)
+				store.Set(key, &updated)
+				return updated.response(req), nil
+			}
+
+			// Decide from the headers whether to cache, so that other bodies are streamed as usual
+			if resp.StatusCode != http.StatusOK || hasDirective(resp.Header, "no-store") {
+				return resp, nil
+			}
+			entry := &CachedResponse{
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header.Clone(),
+				Expires:    expires(resp.Header),
+			}
+			if !entry.cacheable() {
+				store.Delete(key)
+				return resp, nil
+			}
+			entry.Vary = varyHeader(req, resp.Header)
+
+			body, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			entry.Body = body
+			store.Set(key, entry)
+			return resp, nil
+		})
+	}
+}
+
+// cacheable reports whether the response is worth storing,
+// it has to be fresh or carry a validator, and must not vary on everything.
+func (c *CachedResponse) cacheable() bool {
+	for _, name := range varyNames(c.Header) {
+		if name == "*" {
+			return false
+		}
+	}
+	return time.Now().Before(c.Expires) || c.Header.Get("ETag") != "" || c.Header.Get("Last-Modified") != ""
+}
+
+// matches reports whether the response was selected by the same request headers as req.
+func (c *CachedResponse) matches(req *http.Request) bool {
+	for _, name := range varyNames(c.Header) {
+		if strings.Join(req.Header.Values(name), ",") != strings.Join(c.Vary.Values(name), ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// varyHeader returns the values of the request headers named by the Vary header h.
+func varyHeader(req *http.Request, h http.Header) http.Header {
+	names := varyNames(h)
+	if len(names) == 0 {
+		return nil
+	}
+	vary := make(http.Header, len(names))
+	for _, name := range names {
+		if vals := req.Header.Values(name); len(vals) > 0 {
+			vary[name] = vals
+		}
+	}
+	return vary
+}
+
+func varyNames(h http.Header) []string {
+	var names []string
+	for _, val := range h.Values("Vary") {
+		for _, name := range strings.Split(val, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+func (c *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(c.StatusCode) + " " + http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// expires returns when a response with the header becomes stale,
+// which is now if it has to be revalidated every time.
+func expires(h http.Header) time.Time {
+	now := time.Now()
+	if hasDirective(h, "no-cache") {
+		return now
+	}
+	for _, directive := range cacheDirectives(h) {
+		if secs, ok := strings.CutPrefix(directive, "max-age="); ok {
+			if n, err := strconv.Atoi(secs); err == nil {
+				return now.Add(time.Duration(n) * time.Second)
+			}
+		}
+	}
+	if at, err := http.ParseTime(h.Get("Expires")); err == nil {
+		return at
+	}
+	return now
+}
+
+func hasDirective(h http.Header, directive string) bool {
+	for _, d := range cacheDirectives(h) {
+		if d == directive {
+			return true
+		}
+	}
+	return false
+}
+
+func cacheDirectives(h http.Header) []string {
+	var directives []string
+	for _, val := range h.Values("Cache-Control") {
+		for _, d := range strings.Split(val, ",") {
+			directives = append(directives, strings.ToLower(strings.TrimSpace(d)))
+		}
+	}
+	return directives
+}
@@ -0,0 +1,116 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	var hits, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		switch r.URL.Path {
+		case "/fresh":
+			w.Header().Set("Cache-Control", "max-age=60")
+		case "/etag":
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Cache-Control", "no-cache")
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		_, _ = w.Write([]byte("body of " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	cli := NewClient(WithBaseURL(server.URL), WithMiddlewares(Cache(NewMemoryCache())))
+	get := func(path string) string {
+		resp := cli.Get(context.Background(), path).Do()
+		require.NoError(t, resp.EnsureSuccess())
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	testCases := []struct {
+		path            string
+		wantHits        int32
+		wantNotModified int32
+	}{
+		{path: "/fresh", wantHits: 1},
+		{path: "/etag", wantHits: 3, wantNotModified: 2},
+		{path: "/no-store", wantHits: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			atomic.StoreInt32(&hits, 0)
+			atomic.StoreInt32(&notModified, 0)
+			for i := 0; i < 3; i++ {
+				assert.Equal(t, "body of "+tc.path, get(tc.path))
+			}
+			assert.Equal(t, tc.wantHits, atomic.LoadInt32(&hits))
+			assert.Equal(t, tc.wantNotModified, atomic.LoadInt32(&notModified))
+		})
+	}
+}
+
+func TestCache_Vary(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept")
+		if r.URL.Path == "/vary-all" {
+			w.Header().Set("Vary", "*")
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Accept") + r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	doer := Cache(NewMemoryCache())(http.DefaultClient)
+	get := func(path string, header ...string) string {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		resp, err := doer.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	testCases := []struct {
+		name     string
+		path     string
+		header   []string
+		want     string
+		wantHits int32
+	}{
+		{name: "miss", path: "/", header: []string{"Accept", "application/json"}, want: "application/json", wantHits: 1},
+		{name: "hit", path: "/", header: []string{"Accept", "application/json"}, want: "application/json", wantHits: 1},
+		{name: "different accept", path: "/", header: []string{"Accept", "text/plain"}, want: "text/plain", wantHits: 2},
+		{name: "authorization bypasses the cache", path: "/", header: []string{"Accept", "text/plain", "Authorization", "token"}, want: "text/plaintoken", wantHits: 3},
+		{name: "vary all", path: "/vary-all", want: "", wantHits: 4},
+		{name: "vary all is never cached", path: "/vary-all", want: "", wantHits: 5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, get(tc.path, tc.header...))
+			assert.Equal(t, tc.wantHits, atomic.LoadInt32(&hits))
+		})
+	}
+}
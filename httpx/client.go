@@ -1,9 +1,16 @@
 package httpx
 
 import (
+	"bytes"
 	"context"
+	"encoding/xml"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ecloudclub/zkit/iox"
 )
@@ -12,6 +19,16 @@ type Request struct {
 	req    *http.Request
 	err    error
 	client *http.Client
+	retry  *retryPolicy
+
+	tokenSource TokenSource
+	middlewares []Middleware
+
+	errorOnNon2xx bool
+	progress      func(sent, total int64)
+	// maxResponseSize limits Response.Bytes
+	maxResponseSize int64
+	timing          *timingRecorder
 }
 
 func NewRequest(ctx context.Context, method string, url string) *Request {
@@ -28,12 +45,87 @@ func (r *Request) JSONBody(val any) *Request {
 	if r.err != nil {
 		return r
 	}
-	r.req.Body = io.NopCloser(iox.NewJSONReader(val))
+	r.req.Body = newJSONBody(val)
+	// Allows the body to be sent again on retries and redirects
+	r.req.GetBody = func() (io.ReadCloser, error) {
+		return newJSONBody(val), nil
+	}
 	r.req.Header.Set("Content-Type", "application/json")
 
 	return r
 }
 
+// jsonBody is a JSON body with a pooled reader, which is put back when the transport closes the body.
+// The transport may call Close concurrently with Read, hence the lock.
+type jsonBody struct {
+	mu sync.Mutex
+	r  *iox.JSONReader
+}
+
+func newJSONBody(val any) *jsonBody {
+	return &jsonBody{r: iox.GetJSONReader(val)}
+}
+
+func (b *jsonBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.r == nil {
+		return 0, os.ErrClosed
+	}
+	return b.r.Read(p)
+}
+
+func (b *jsonBody) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.r != nil {
+		iox.PutJSONReader(b.r)
+		b.r = nil
+	}
+	return nil
+}
+
+// XMLBody uses XML as req.Body.
+func (r *Request) XMLBody(val any) *Request {
+	if r.err != nil {
+		return r
+	}
+	data, err := xml.Marshal(val)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	r.setBody(data)
+	r.req.Header.Set("Content-Type", "application/xml")
+
+	return r
+}
+
+// ProtoBody uses the protobuf encoding of m as req.Body.
+func (r *Request) ProtoBody(m proto.Message) *Request {
+	if r.err != nil {
+		return r
+	}
+	data, err := proto.Marshal(m)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	r.setBody(data)
+	r.req.Header.Set("Content-Type", "application/x-protobuf")
+
+	return r
+}
+
+// setBody uses data as req.Body, which can be sent again on retries and redirects.
+func (r *Request) setBody(data []byte) {
+	r.req.Body = io.NopCloser(bytes.NewReader(data))
+	r.req.ContentLength = int64(len(data))
+	r.req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+}
+
 // Client replaces the default Client with the custom implementation passed in.
 func (r *Request) Client(cli *http.Client) *Request {
 	r.client = cli
@@ -48,6 +140,15 @@ func (r *Request) AddHeader(key string, val string) *Request {
 	return r
 }
 
+// AddCookie adds a cookie to the request.
+func (r *Request) AddCookie(cookie *http.Cookie) *Request {
+	if r.err != nil {
+		return r
+	}
+	r.req.AddCookie(cookie)
+	return r
+}
+
 func (r *Request) AddParam(key string, val string) *Request {
 	if r.err != nil {
 		return r
@@ -64,9 +165,33 @@ func (r *Request) Do() *Response {
 			err: r.err,
 		}
 	}
-	resp, err := r.client.Do(r.req)
-	return &Response{
-		Response: resp,
-		err:      err,
+	if err := r.authorize(); err != nil {
+		return &Response{
+			err: err,
+		}
+	}
+	if r.timing != nil {
+		r.req = r.req.WithContext(httptrace.WithClientTrace(r.req.Context(), r.timing.clientTrace()))
+	}
+	r.trackProgress()
+	var (
+		resp *http.Response
+		err  error
+	)
+	doer := r.doer()
+	if r.retry != nil {
+		resp, err = r.doWithRetry(doer)
+	} else {
+		resp, err = doer.Do(r.req)
+	}
+	res := &Response{
+		Response:    resp,
+		err:         err,
+		maxBodySize: r.maxResponseSize,
+		timing:      r.timing,
+	}
+	if r.errorOnNon2xx {
+		_ = res.EnsureSuccess()
 	}
+	return res
 }
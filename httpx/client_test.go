@@ -3,12 +3,16 @@ package httpx
 import (
 	"context"
 	"errors"
+	"io"
 	"net"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type User struct {
@@ -103,3 +107,51 @@ func TestRequest_AddParam(t *testing.T) {
 	assert.NotNil(t, req2.err)
 	assert.Nil(t, req2.req)
 }
+
+func TestRequest_XMLBody(t *testing.T) {
+	req := NewRequest(context.Background(), http.MethodPost, "/123")
+	req = req.XMLBody(User{Name: "Tom"})
+	assert.NoError(t, req.err)
+	assert.Equal(t, "application/xml", req.req.Header.Get("Content-Type"))
+	data, err := io.ReadAll(req.req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "<User><Name>Tom</Name></User>", string(data))
+
+	// can't be marshaled
+	req = NewRequest(context.Background(), http.MethodPost, "/123").XMLBody(make(chan int))
+	assert.Error(t, req.err)
+}
+
+func TestResponse_XMLReceive(t *testing.T) {
+	resp := &Response{Response: &http.Response{
+		Body: io.NopCloser(strings.NewReader("<User><Name>Tom</Name></User>")),
+	}}
+	var u User
+	assert.NoError(t, resp.XMLReceive(&u))
+	assert.Equal(t, "Tom", u.Name)
+
+	resp = &Response{err: errors.New("mock error")}
+	assert.Equal(t, errors.New("mock error"), resp.XMLReceive(&u))
+}
+
+func TestRequest_ProtoBody(t *testing.T) {
+	req := NewRequest(context.Background(), http.MethodPost, "/123")
+	req = req.ProtoBody(wrapperspb.String("Tom"))
+	assert.NoError(t, req.err)
+	assert.Equal(t, "application/x-protobuf", req.req.Header.Get("Content-Type"))
+
+	resp := &Response{Response: &http.Response{Body: req.req.Body}}
+	msg := &wrapperspb.StringValue{}
+	assert.NoError(t, resp.ProtoReceive(msg))
+	assert.True(t, proto.Equal(wrapperspb.String("Tom"), msg))
+
+	resp = &Response{err: errors.New("mock error")}
+	assert.Equal(t, errors.New("mock error"), resp.ProtoReceive(msg))
+}
+
+func TestRequest_AddCookie(t *testing.T) {
+	req := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		AddCookie(&http.Cookie{Name: "a", Value: "1"}).
+		AddCookie(&http.Cookie{Name: "b", Value: "2"})
+	assert.Equal(t, "a=1; b=2", req.req.Header.Get("Cookie"))
+}
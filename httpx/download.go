@@ -0,0 +1,122 @@
+package httpx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// ErrChecksumMismatch is returned when the downloaded data doesn't match the expected checksum.
+var ErrChecksumMismatch = errors.New("zkit: 校验和不匹配")
+
+type download struct {
+	progress func(written, total int64)
+	hash     hash.Hash
+	checksum []byte
+}
+
+// WithProgress calls fn every time data is written, with the number of bytes written so far
+// and the total size, which is -1 if unknown.
+func WithProgress(fn func(written, total int64)) option.Option[download] {
+	return func(d *download) {
+		d.progress = fn
+	}
+}
+
+// WithChecksum verifies that the digest of the data computed by h is checksum.
+func WithChecksum(h hash.Hash, checksum []byte) option.Option[download] {
+	return func(d *download) {
+		d.hash = h
+		d.checksum = checksum
+	}
+}
+
+// WithSHA256 verifies that the SHA-256 digest of the data is the hex encoded checksum.
+func WithSHA256(checksum string) option.Option[download] {
+	return func(d *download) {
+		sum, err := hex.DecodeString(checksum)
+		if err != nil {
+			// Never matches
+			sum = []byte(checksum)
+		}
+		d.hash = sha256.New()
+		d.checksum = sum
+	}
+}
+
+// WriteTo streams the body to w without loading it into memory, then closes the body.
+// It returns ErrChecksumMismatch if the checksum set by WithChecksum doesn't match,
+// in which case the data has already been written to w.
+func (r *Response) WriteTo(w io.Writer, opts ...option.Option[download]) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	defer r.Body.Close()
+
+	d := &download{}
+	option.Apply(d, opts...)
+	if d.hash != nil {
+		w = io.MultiWriter(w, d.hash)
+	}
+	if d.progress != nil {
+		w = &progressWriter{w: w, total: r.ContentLength, fn: d.progress}
+	}
+
+	n, err := io.Copy(w, r.Body)
+	if err != nil {
+		return n, err
+	}
+	if d.hash != nil && !bytes.Equal(d.hash.Sum(nil), d.checksum) {
+		return n, ErrChecksumMismatch
+	}
+	return n, nil
+}
+
+// ToFile downloads the body to the file at path.
+// The data is written to a temporary file in the same directory which replaces path once completed,
+// so path is left untouched if the download fails or the checksum doesn't match.
+func (r *Response) ToFile(path string, opts ...option.Option[download]) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		_ = r.Body.Close()
+		return err
+	}
+	_, err = r.WriteTo(f, opts...)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("zkit: 下载到 %s 失败: %w", path, err)
+	}
+	return nil
+}
+
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	total   int64
+	fn      func(written, total int64)
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	pw.fn(pw.written, pw.total)
+	return n, err
+}
@@ -0,0 +1,60 @@
+package httpx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBodyResponse(body string) *Response {
+	return &Response{Response: &http.Response{
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(strings.NewReader(body)),
+	}}
+}
+
+func TestResponse_WriteTo(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello world"))
+	var progress []int64
+	buf := &bytes.Buffer{}
+	n, err := newBodyResponse("hello world").WriteTo(buf,
+		WithSHA256(hex.EncodeToString(sum[:])),
+		WithProgress(func(written, total int64) {
+			assert.Equal(t, int64(11), total)
+			progress = append(progress, written)
+		}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), n)
+	assert.Equal(t, "hello world", buf.String())
+	assert.Equal(t, int64(11), progress[len(progress)-1])
+
+	_, err = newBodyResponse("hello world").WriteTo(io.Discard, WithSHA256("00"))
+	assert.Equal(t, ErrChecksumMismatch, err)
+}
+
+func TestResponse_ToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, newBodyResponse("hello world").ToFile(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+
+	// the file is left untouched on failure
+	err = newBodyResponse("bye").ToFile(path, WithSHA256("00"))
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
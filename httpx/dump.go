@@ -0,0 +1,76 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sort"
+	"strings"
+)
+
+// Dump renders the request in HTTP/1.x wire format for debugging,
+// with the values of sensitive headers such as Authorization and Cookie redacted.
+// The body is only included if it can be rebuilt, see Request.Retry,
+// and the token of TokenSource is not included as it is obtained when the request is sent.
+func (r *Request) Dump() (string, error) {
+	req, err := r.redactedCopy()
+	if err != nil {
+		return "", err
+	}
+	data, err := httputil.DumpRequestOut(req, req.Body != nil)
+	return string(data), err
+}
+
+// AsCurl renders the request as a curl command to reproduce it, e.g. in bug reports.
+// Sensitive headers and the body are handled like Dump, and the password in the URL is redacted.
+func (r *Request) AsCurl() (string, error) {
+	req, err := r.redactedCopy()
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("curl -X " + req.Method + " " + shellQuote(req.URL.Redacted()))
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, val := range req.Header[key] {
+			sb.WriteString(" -H " + shellQuote(key+": "+val))
+		}
+	}
+	if req.Body != nil {
+		defer req.Body.Close()
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(" --data-raw " + shellQuote(string(data)))
+	}
+	return sb.String(), nil
+}
+
+// redactedCopy returns a copy of the request with sensitive headers redacted and a fresh body.
+func (r *Request) redactedCopy() (*http.Request, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	req := r.req.Clone(r.req.Context())
+	req.Header = redactHeader(req.Header, sensitiveHeaders)
+	req.Body = nil
+	if r.req.GetBody != nil {
+		body, err := r.req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	return req, nil
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,51 @@
+package httpx
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxErrorBodySize is the maximum number of bytes of the body kept in HTTPError.
+const maxErrorBodySize = 64 << 10
+
+// HTTPError is the error of a response whose status code is not 2xx.
+type HTTPError struct {
+	StatusCode int
+	Header     http.Header
+	// Body holds at most the first 64KB of the body
+	Body []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("zkit: HTTP 请求失败, 状态码 %d: %s", e.StatusCode, e.Body)
+}
+
+// EnsureSuccess returns an *HTTPError if the status code is not 2xx,
+// in which case the body is read, drained and closed.
+// It returns the error of the request if any.
+func (r *Response) EnsureSuccess() error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.StatusCode >= 200 && r.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(r.Body, maxErrorBodySize))
+	// Drain the rest so that the connection can be reused
+	_, _ = io.Copy(io.Discard, r.Body)
+	_ = r.Body.Close()
+	r.err = &HTTPError{
+		StatusCode: r.StatusCode,
+		Header:     r.Header,
+		Body:       body,
+	}
+	return r.err
+}
+
+// ErrorOnNon2xx makes Do turn non-2xx responses into *HTTPError, see Response.EnsureSuccess.
+func (r *Request) ErrorOnNon2xx() *Request {
+	r.errorOnNon2xx = true
+	return r
+}
@@ -0,0 +1,40 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponse_EnsureSuccess(t *testing.T) {
+	resp := newBodyResponse("ok")
+	resp.StatusCode = http.StatusOK
+	assert.NoError(t, resp.EnsureSuccess())
+
+	resp = newBodyResponse("not found")
+	resp.StatusCode = http.StatusNotFound
+	err := resp.EnsureSuccess()
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	assert.Equal(t, "not found", string(httpErr.Body))
+	// the following receive calls get the error too
+	var u User
+	assert.Equal(t, err, resp.JSONReceive(&u))
+
+	resp = &Response{err: errors.New("mock error")}
+	assert.Equal(t, errors.New("mock error"), resp.EnsureSuccess())
+}
+
+func TestRequest_ErrorOnNon2xx(t *testing.T) {
+	resp := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		ErrorOnNon2xx().
+		Use(stubDoer(http.StatusBadGateway, "bad gateway")).
+		Do()
+	var httpErr *HTTPError
+	assert.True(t, errors.As(resp.err, &httpErr))
+	assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+}
@@ -0,0 +1,28 @@
+package httpx
+
+import (
+	"net/http"
+	"time"
+)
+
+// OnRequest registers a hook called with every outgoing request, including retries.
+func (r *Request) OnRequest(hook func(req *http.Request)) *Request {
+	return r.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			hook(req)
+			return next.Do(req)
+		})
+	})
+}
+
+// OnResponse registers a hook called with the result of every attempt and how long it took.
+func (r *Request) OnResponse(hook func(req *http.Request, resp *http.Response, err error, elapsed time.Duration)) *Request {
+	return r.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			hook(req, resp, err, time.Since(start))
+			return resp, err
+		})
+	})
+}
@@ -0,0 +1,278 @@
+package httpx
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// Client holds the settings shared by requests to the same service,
+// and creates pre-configured requests with paths relative to its base URL.
+type Client struct {
+	baseURL     string
+	header      http.Header
+	timeout     time.Duration
+	middlewares []Middleware
+	jar         http.CookieJar
+	tlsConfig   *tls.Config
+	proxy       func(*http.Request) (*url.URL, error)
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	client      *http.Client
+
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	http2               *bool
+
+	idempotencyKey bool
+	// err is an error of the options, which is returned by every request
+	err error
+}
+
+// WithBaseURL sets the URL which the paths of requests are relative to.
+func WithBaseURL(baseURL string) option.Option[Client] {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHeader adds a header to every request.
+func WithHeader(key, val string) option.Option[Client] {
+	return func(c *Client) {
+		c.header.Add(key, val)
+	}
+}
+
+// WithTimeout sets the time limit of every request, including reading the body.
+func WithTimeout(d time.Duration) option.Option[Client] {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithMiddlewares adds middlewares to every request, before the ones added by Request.Use.
+func WithMiddlewares(mws ...Middleware) option.Option[Client] {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mws...)
+	}
+}
+
+// WithHTTPClient sets the underlying http.Client, which defaults to http.DefaultClient.
+func WithHTTPClient(cli *http.Client) option.Option[Client] {
+	return func(c *Client) {
+		c.client = cli
+	}
+}
+
+// WithCookieJar sets the cookie jar storing the cookies of responses and sending them with requests,
+// e.g. to keep the session after logging in. See net/http/cookiejar.
+func WithCookieJar(jar http.CookieJar) option.Option[Client] {
+	return func(c *Client) {
+		c.jar = jar
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the transport.
+// It replaces the configuration made by the other TLS options before it.
+func WithTLSConfig(cfg *tls.Config) option.Option[Client] {
+	return func(c *Client) {
+		c.tlsConfig = cfg.Clone()
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server certificate, for testing only.
+func WithInsecureSkipVerify() option.Option[Client] {
+	return func(c *Client) {
+		c.tls().InsecureSkipVerify = true
+	}
+}
+
+// WithClientCert loads a client certificate for mutual TLS from a pair of PEM encoded files.
+func WithClientCert(certFile, keyFile string) option.Option[Client] {
+	return func(c *Client) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			c.err = err
+			return
+		}
+		cfg := c.tls()
+		cfg.Certificates = append(cfg.Certificates, cert)
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL.
+func WithProxy(proxyURL string) option.Option[Client] {
+	return func(c *Client) {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			c.err = err
+			return
+		}
+		c.proxy = http.ProxyURL(u)
+	}
+}
+
+// WithDialContext sets the function dialing the connections of the transport.
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) option.Option[Client] {
+	return func(c *Client) {
+		c.dialContext = dial
+	}
+}
+
+// WithUnixSocket connects to the unix domain socket at path whatever the host of the URL,
+// e.g. to talk to the Docker daemon with http://localhost/containers/json.
+func WithUnixSocket(path string) option.Option[Client] {
+	return WithDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", path)
+	})
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle connections kept for each host,
+// which is 2 by default in net/http and too few for services calling the same host concurrently.
+func WithMaxIdleConnsPerHost(n int) option.Option[Client] {
+	return func(c *Client) {
+		c.maxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept in the pool.
+func WithIdleConnTimeout(d time.Duration) option.Option[Client] {
+	return func(c *Client) {
+		c.idleConnTimeout = d
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2 over TLS, which is enabled by default.
+func WithHTTP2(enabled bool) option.Option[Client] {
+	return func(c *Client) {
+		c.http2 = &enabled
+	}
+}
+
+func (c *Client) tls() *tls.Config {
+	if c.tlsConfig == nil {
+		c.tlsConfig = &tls.Config{}
+	}
+	return c.tlsConfig
+}
+
+// NewClient returns a Client, which is safe for concurrent use.
+func NewClient(opts ...option.Option[Client]) *Client {
+	c := &Client{
+		header: http.Header{},
+		client: http.DefaultClient,
+	}
+	option.Apply(c, opts...)
+	c.client = c.httpClient()
+	return c
+}
+
+// httpClient returns a copy of the underlying client with the options applied,
+// so that the client passed in, which may be shared, is not modified.
+func (c *Client) httpClient() *http.Client {
+	cli := *c.client
+	if c.timeout > 0 {
+		cli.Timeout = c.timeout
+	}
+	if c.jar != nil {
+		cli.Jar = c.jar
+	}
+	if c.tlsConfig != nil || c.proxy != nil || c.dialContext != nil ||
+		c.maxIdleConnsPerHost > 0 || c.idleConnTimeout > 0 || c.http2 != nil {
+		var transport *http.Transport
+		switch t := cli.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = t.Clone()
+		default:
+			c.err = errors.New("zkit: TLS、代理、拨号和连接池选项需要 *http.Transport")
+			return &cli
+		}
+		if c.tlsConfig != nil {
+			transport.TLSClientConfig = c.tlsConfig
+		}
+		if c.proxy != nil {
+			transport.Proxy = c.proxy
+		}
+		if c.dialContext != nil {
+			transport.DialContext = c.dialContext
+		}
+		if c.maxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = c.maxIdleConnsPerHost
+			if transport.MaxIdleConns > 0 && transport.MaxIdleConns < c.maxIdleConnsPerHost {
+				transport.MaxIdleConns = c.maxIdleConnsPerHost
+			}
+		}
+		if c.idleConnTimeout > 0 {
+			transport.IdleConnTimeout = c.idleConnTimeout
+		}
+		if c.http2 != nil {
+			transport.ForceAttemptHTTP2 = *c.http2
+			if !*c.http2 {
+				// A non-nil empty map disables HTTP/2
+				transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+				if transport.TLSClientConfig != nil {
+					transport.TLSClientConfig = transport.TLSClientConfig.Clone()
+					transport.TLSClientConfig.NextProtos = nil
+				}
+			}
+		}
+		cli.Transport = transport
+	}
+	return &cli
+}
+
+// NewRequest returns a request to path, which is relative to the base URL unless it is an absolute URL.
+func (c *Client) NewRequest(ctx context.Context, method string, path string) *Request {
+	r := NewRequest(ctx, method, c.url(path)).Client(c.client)
+	if c.err != nil {
+		r.err = c.err
+	}
+	if r.err == nil {
+		for key, vals := range c.header {
+			for _, val := range vals {
+				r.req.Header.Add(key, val)
+			}
+		}
+	}
+	r.middlewares = append(r.middlewares, c.middlewares...)
+	if c.idempotencyKey && needsIdempotencyKey(method) {
+		r.IdempotencyKey()
+	}
+	return r
+}
+
+func (c *Client) Get(ctx context.Context, path string) *Request {
+	return c.NewRequest(ctx, http.MethodGet, path)
+}
+
+func (c *Client) Post(ctx context.Context, path string) *Request {
+	return c.NewRequest(ctx, http.MethodPost, path)
+}
+
+func (c *Client) Put(ctx context.Context, path string) *Request {
+	return c.NewRequest(ctx, http.MethodPut, path)
+}
+
+func (c *Client) Patch(ctx context.Context, path string) *Request {
+	return c.NewRequest(ctx, http.MethodPatch, path)
+}
+
+func (c *Client) Delete(ctx context.Context, path string) *Request {
+	return c.NewRequest(ctx, http.MethodDelete, path)
+}
+
+func (c *Client) url(path string) string {
+	if c.baseURL == "" || strings.Contains(path, "://") {
+		return path
+	}
+	return strings.TrimRight(c.baseURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
@@ -0,0 +1,192 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.RequestURI() + " " + r.Header.Get("X-Token")))
+	}))
+	defer server.Close()
+
+	var calls int
+	cli := NewClient(
+		WithBaseURL(server.URL+"/api/"),
+		WithHeader("X-Token", "token"),
+		WithTimeout(50*time.Millisecond),
+		WithMiddlewares(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				return next.Do(req)
+			})
+		}),
+	)
+	// the client passed in is not modified
+	assert.Equal(t, time.Duration(0), http.DefaultClient.Timeout)
+
+	resp := cli.Get(context.Background(), "/users/1").AddParam("a", "b").Do()
+	require.NoError(t, resp.err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "GET /api/users/1?a=b token", string(body))
+	assert.Equal(t, 1, calls)
+
+	resp = cli.Delete(context.Background(), server.URL+"/users/1").Do()
+	require.NoError(t, resp.err)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE /users/1 token", string(body))
+
+	resp = cli.Post(context.Background(), server.URL+"/slow").Do()
+	assert.Error(t, resp.err)
+}
+
+func TestClient_CookieJar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1", Path: "/"})
+			return
+		}
+		c, err := r.Cookie("session")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(c.Value))
+	}))
+	defer server.Close()
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	cli := NewClient(WithBaseURL(server.URL), WithCookieJar(jar))
+
+	resp := cli.Get(context.Background(), "/me").Do()
+	require.NoError(t, resp.err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	require.NoError(t, cli.Post(context.Background(), "/login").Do().err)
+	resp = cli.Get(context.Background(), "/me").Do()
+	require.NoError(t, resp.err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "s1", string(body))
+}
+
+func TestClient_Transport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the certificate of the test server is not trusted
+	resp := NewClient(WithBaseURL(server.URL)).Get(context.Background(), "/").Do()
+	assert.Error(t, resp.err)
+
+	resp = NewClient(WithBaseURL(server.URL), WithInsecureSkipVerify()).Get(context.Background(), "/").Do()
+	require.NoError(t, resp.err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+	resp = NewClient(WithProxy(proxy.URL)).Get(context.Background(), "http://example.com/users").Do()
+	require.NoError(t, resp.err)
+	assert.Equal(t, "http://example.com/users", proxied)
+
+	// errors of the options are returned by the requests
+	resp = NewClient(WithClientCert("not_exist.crt", "not_exist.key")).Get(context.Background(), "/").Do()
+	assert.Error(t, resp.err)
+}
+
+func TestClient_UnixSocket(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "test.sock")
+	lis, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix socket is not supported: %v", err)
+	}
+	server := &httptest.Server{
+		Listener: lis,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})},
+	}
+	server.Start()
+	defer server.Close()
+
+	resp := NewClient(WithUnixSocket(sock)).Get(context.Background(), "http://localhost/hello").Do()
+	require.NoError(t, resp.err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestClient_ConnPool(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	testCases := []struct {
+		name      string
+		opts      []option.Option[Client]
+		wantProto string
+	}{
+		{
+			name:      "http2",
+			opts:      []option.Option[Client]{WithMaxIdleConnsPerHost(10), WithIdleConnTimeout(time.Minute)},
+			wantProto: "HTTP/2.0",
+		},
+		{
+			name:      "http2 disabled",
+			opts:      []option.Option[Client]{WithHTTP2(false)},
+			wantProto: "HTTP/1.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]option.Option[Client]{WithBaseURL(server.URL), WithInsecureSkipVerify()}, tc.opts...)
+			c := NewClient(opts...)
+			require.NoError(t, c.err)
+
+			for i := 0; i < 2; i++ {
+				resp := c.Get(context.Background(), "/").TraceTiming().Do()
+				proto, err := resp.String()
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantProto, proto)
+
+				timing := resp.Timing()
+				assert.Equal(t, i > 0, timing.ConnReused)
+				assert.Positive(t, timing.TTFB)
+				if i == 0 {
+					assert.Positive(t, timing.Connect)
+					assert.Positive(t, timing.TLS)
+				}
+			}
+		})
+	}
+
+	transport := NewClient(WithMaxIdleConnsPerHost(200), WithIdleConnTimeout(time.Minute)).client.Transport.(*http.Transport)
+	assert.Equal(t, 200, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+}
@@ -0,0 +1,146 @@
+package httpxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay responds with the interactions in the golden file without sending requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and saves the interactions into the golden file.
+	ModeRecord
+)
+
+// Interaction is a request and its response saved in a golden file.
+type Interaction struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// sensitiveHeaders are redacted in golden files, like httpx.Request.Dump does.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+const redacted = "[REDACTED]"
+
+// Recorder is an http.RoundTripper recording real responses into a golden file
+// and replaying them in later test runs.
+// The values of sensitive headers such as Set-Cookie and the userinfo of URLs are redacted in the golden file,
+// the responses returned while recording are not.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	loaded       bool
+	used         []bool
+}
+
+// NewRecorder returns a Recorder using the golden file at path.
+// In ModeRecord, requests are sent by next, which defaults to http.DefaultTransport.
+func NewRecorder(path string, mode Mode, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, mode: mode, next: next}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Method: req.Method,
+		URL:    req.URL.Redacted(),
+		Status: resp.StatusCode,
+		Header: redactHeader(resp.Header),
+		Body:   string(body),
+	})
+	// Save every time so that nothing is lost if the test fails halfway
+	if err = r.save(); err != nil {
+		return nil, err
+	}
+	return newResponse(req, resp.StatusCode, resp.Header, body), nil
+}
+
+// replay responds with the first unused interaction with the same method and URL.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	closeBody(req)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	url := req.URL.Redacted()
+	for i, it := range r.interactions {
+		if r.used[i] || it.Method != req.Method || it.URL != url {
+			continue
+		}
+		r.used[i] = true
+		return newResponse(req, it.Status, it.Header, []byte(it.Body)), nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoRoute, req.Method, url)
+}
+
+func (r *Recorder) load() error {
+	if r.loaded {
+		return nil
+	}
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, &r.interactions); err != nil {
+		return err
+	}
+	r.used = make([]bool, len(r.interactions))
+	r.loaded = true
+	return nil
+}
+
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := h[name]; ok {
+			h.Set(name, redacted)
+		}
+	}
+	return h
+}
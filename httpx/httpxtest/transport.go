@@ -0,0 +1,171 @@
+// Package httpxtest provides http.RoundTripper implementations
+// to test code using httpx without spinning up servers.
+package httpxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// ErrNoRoute is returned when no route matches the request.
+var ErrNoRoute = errors.New("httpxtest: no route matches the request")
+
+// Transport is a programmable http.RoundTripper responding to requests with the first route matching them.
+type Transport struct {
+	mu     sync.Mutex
+	routes []*Route
+}
+
+func NewTransport() *Transport {
+	return &Transport{}
+}
+
+// On adds a route matching requests by method and path, which responds with 200 by default.
+// An empty method matches any method, and pattern is matched with path.Match, e.g. "/users/*".
+func (t *Transport) On(method, pattern string) *Route {
+	r := &Route{
+		t:       t,
+		method:  method,
+		pattern: pattern,
+		status:  http.StatusOK,
+		header:  http.Header{},
+	}
+	t.mu.Lock()
+	t.routes = append(t.routes, r)
+	t.mu.Unlock()
+	return r
+}
+
+// Client returns an http.Client using the transport.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body is never read, but it must be closed as http.RoundTripper requires
+	closeBody(req)
+	t.mu.Lock()
+	var route *Route
+	for _, r := range t.routes {
+		if r.match(req) {
+			route = r
+			r.hits++
+			break
+		}
+	}
+	t.mu.Unlock()
+	if route == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRoute, req.Method, req.URL)
+	}
+	return route.respond(req)
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// Route is the response of the requests matching it.
+// It must be configured before the requests are sent.
+type Route struct {
+	t       *Transport
+	method  string
+	pattern string
+
+	status int
+	header http.Header
+	body   []byte
+	delay  time.Duration
+	err    error
+	hits   int
+}
+
+// Respond sets the status code and the body of the response.
+func (r *Route) Respond(status int, body string) *Route {
+	r.status = status
+	r.body = []byte(body)
+	return r
+}
+
+// RespondJSON sets the status code and the JSON encoded body of the response.
+func (r *Route) RespondJSON(status int, val any) *Route {
+	data, err := json.Marshal(val)
+	if err != nil {
+		panic(err)
+	}
+	r.status = status
+	r.body = data
+	r.header.Set("Content-Type", "application/json")
+	return r
+}
+
+// Header adds a header to the response.
+func (r *Route) Header(key, val string) *Route {
+	r.header.Add(key, val)
+	return r
+}
+
+// Delay waits for d before responding, or until the context of the request is done.
+func (r *Route) Delay(d time.Duration) *Route {
+	r.delay = d
+	return r
+}
+
+// Fail makes the requests fail with err instead of responding.
+func (r *Route) Fail(err error) *Route {
+	r.err = err
+	return r
+}
+
+// Hits returns the number of requests matched by the route.
+func (r *Route) Hits() int {
+	r.t.mu.Lock()
+	defer r.t.mu.Unlock()
+	return r.hits
+}
+
+func (r *Route) match(req *http.Request) bool {
+	if r.method != "" && r.method != req.Method {
+		return false
+	}
+	ok, err := path.Match(r.pattern, req.URL.Path)
+	return err == nil && ok
+}
+
+func (r *Route) respond(req *http.Request) (*http.Response, error) {
+	if r.delay > 0 {
+		timer := time.NewTimer(r.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return newResponse(req, r.status, r.header, r.body), nil
+}
+
+func newResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
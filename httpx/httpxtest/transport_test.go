@@ -0,0 +1,125 @@
+package httpxtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ecloudclub/zkit/httpx"
+)
+
+type User struct {
+	Name string
+}
+
+func TestTransport(t *testing.T) {
+	tr := NewTransport()
+	users := tr.On(http.MethodGet, "/users/*").RespondJSON(http.StatusOK, User{Name: "Tom"})
+	tr.On(http.MethodPost, "/users").Respond(http.StatusCreated, "")
+	tr.On("", "/slow").Delay(time.Second)
+	tr.On("", "/broken").Fail(errors.New("mock error"))
+
+	cli := httpx.NewClient(httpx.WithBaseURL("http://api.test"), httpx.WithHTTPClient(tr.Client()))
+	ctx := context.Background()
+
+	u, err := httpx.DecodeJSON[User](cli.Get(ctx, "/users/1").Do())
+	require.NoError(t, err)
+	assert.Equal(t, User{Name: "Tom"}, u)
+	assert.Equal(t, 1, users.Hits())
+
+	resp := cli.Post(ctx, "/users").Do()
+	require.NoError(t, resp.EnsureSuccess())
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, cli.Get(timeout, "/slow").Do().EnsureSuccess(), context.DeadlineExceeded)
+	assert.Error(t, cli.Get(ctx, "/broken").Do().EnsureSuccess())
+	assert.ErrorIs(t, cli.Delete(ctx, "/users/1").Do().EnsureSuccess(), ErrNoRoute)
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestTransport_CloseBody(t *testing.T) {
+	tr := NewTransport()
+	tr.On(http.MethodPost, "/users").Respond(http.StatusCreated, "")
+
+	testCases := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{name: "matched", path: "/users"},
+		{name: "no route", path: "/orders", wantErr: ErrNoRoute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := &closeRecorder{Reader: strings.NewReader("{}")}
+			req, err := http.NewRequest(http.MethodPost, "http://api.test"+tc.path, body)
+			require.NoError(t, err)
+			_, err = tr.RoundTrip(req)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.True(t, body.closed)
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.Path)
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write([]byte("hello " + r.URL.Path))
+	}))
+	golden := filepath.Join(t.TempDir(), "golden.json")
+
+	get := func(rt http.RoundTripper, path string) (string, error) {
+		resp := httpx.NewRequest(context.Background(), http.MethodGet, server.URL+path).
+			Client(&http.Client{Transport: rt}).Do()
+		if err := resp.EnsureSuccess(); err != nil {
+			return "", err
+		}
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	rec := NewRecorder(golden, ModeRecord, nil)
+	body, err := get(rec, "/a")
+	require.NoError(t, err)
+	assert.Equal(t, "hello /a", body)
+	_, err = get(rec, "/b")
+	require.NoError(t, err)
+	data, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	// replayed without the server
+	server.Close()
+	rep := NewRecorder(golden, ModeReplay, nil)
+	body, err = get(rep, "/b")
+	require.NoError(t, err)
+	assert.Equal(t, "hello /b", body)
+	body, err = get(rep, "/a")
+	require.NoError(t, err)
+	assert.Equal(t, "hello /a", body)
+	_, err = get(rep, "/a")
+	assert.ErrorIs(t, err, ErrNoRoute)
+}
@@ -0,0 +1,37 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+const headerIdempotencyKey = "Idempotency-Key"
+
+// IdempotencyKey attaches a random UUID as the Idempotency-Key header,
+// it is generated once, so that all the retries of the request carry the same key.
+// A key already set is kept.
+func (r *Request) IdempotencyKey() *Request {
+	if r.err != nil {
+		return r
+	}
+	if r.req.Header.Get(headerIdempotencyKey) == "" {
+		r.req.Header.Set(headerIdempotencyKey, uuid.NewString())
+	}
+	return r
+}
+
+// WithIdempotencyKey makes the client attach an Idempotency-Key to every POST and PATCH request,
+// see Request.IdempotencyKey.
+func WithIdempotencyKey() option.Option[Client] {
+	return func(c *Client) {
+		c.idempotencyKey = true
+	}
+}
+
+// needsIdempotencyKey reports whether a request with the method is not idempotent by itself.
+func needsIdempotencyKey(method string) bool {
+	return method == http.MethodPost || method == http.MethodPatch
+}
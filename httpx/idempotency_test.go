@@ -0,0 +1,40 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_IdempotencyKey(t *testing.T) {
+	var keys []string
+	resp := NewRequest(context.Background(), http.MethodPost, "http://localhost").
+		IdempotencyKey().
+		Retry(2, WithRetryBackoff(0, 0)).
+		Use(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				keys = append(keys, req.Header.Get("Idempotency-Key"))
+				return next.Do(req)
+			})
+		}, stubDoer(http.StatusServiceUnavailable, "")).
+		Do()
+	assert.NoError(t, resp.err)
+	assert.Len(t, keys, 3)
+	_, err := uuid.Parse(keys[0])
+	assert.NoError(t, err)
+	// reused across retries
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, keys[0], keys[2])
+
+	cli := NewClient(WithIdempotencyKey())
+	assert.NotEmpty(t, cli.Post(context.Background(), "http://localhost").req.Header.Get("Idempotency-Key"))
+	assert.Empty(t, cli.Get(context.Background(), "http://localhost").req.Header.Get("Idempotency-Key"))
+	// a key set explicitly is kept
+	req := NewRequest(context.Background(), http.MethodPost, "http://localhost").
+		AddHeader("Idempotency-Key", "key").
+		IdempotencyKey()
+	assert.Equal(t, "key", req.req.Header.Get("Idempotency-Key"))
+}
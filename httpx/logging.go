@@ -0,0 +1,118 @@
+package httpx
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are redacted when requests are logged or dumped.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+type logConfig struct {
+	maxBodySize   int
+	redactHeaders []string
+}
+
+// WithLogBody logs the request and response bodies truncated to maxSize bytes.
+// The request body is only logged if it can be rebuilt, see Request.Retry.
+func WithLogBody(maxSize int) option.Option[logConfig] {
+	return func(c *logConfig) {
+		c.maxBodySize = maxSize
+	}
+}
+
+// WithRedactHeaders redacts the values of more headers besides
+// Authorization, Proxy-Authorization, Cookie and Set-Cookie.
+func WithRedactHeaders(names ...string) option.Option[logConfig] {
+	return func(c *logConfig) {
+		c.redactHeaders = append(c.redactHeaders, names...)
+	}
+}
+
+// Logging returns a middleware logging the method, URL, headers, status and latency of every request.
+// Sensitive headers are redacted, and l can be built on zapx.CustomCore to mask sensitive fields further.
+// Requests failing with an error are logged at error level, and non-2xx responses at warn level.
+func Logging(l *zap.Logger, opts ...option.Option[logConfig]) Middleware {
+	cfg := &logConfig{
+		redactHeaders: slices.Clone(sensitiveHeaders),
+	}
+	option.Apply(cfg, opts...)
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			fields := []zap.Field{
+				zap.String("method", req.Method),
+				zap.String("url", req.URL.Redacted()),
+				zap.Any("request_header", cfg.redact(req.Header)),
+			}
+			if cfg.maxBodySize > 0 && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					fields = append(fields, zap.ByteString("request_body", cfg.peek(body)))
+					_ = body.Close()
+				}
+			}
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			fields = append(fields, zap.Duration("latency", time.Since(start)))
+			if err != nil {
+				l.Error("httpx: request failed", append(fields, zap.Error(err))...)
+				return resp, err
+			}
+
+			fields = append(fields,
+				zap.Int("status", resp.StatusCode),
+				zap.Any("response_header", cfg.redact(resp.Header)))
+			if cfg.maxBodySize > 0 {
+				var body []byte
+				body, resp.Body = cfg.peekResponse(resp.Body)
+				fields = append(fields, zap.ByteString("response_body", body))
+			}
+			if resp.StatusCode >= http.StatusBadRequest {
+				l.Warn("httpx: request done", fields...)
+			} else {
+				l.Info("httpx: request done", fields...)
+			}
+			return resp, nil
+		})
+	}
+}
+
+func (c *logConfig) redact(h http.Header) http.Header {
+	return redactHeader(h, c.redactHeaders)
+}
+
+// redactHeader returns a copy of h with the values of names replaced.
+func redactHeader(h http.Header, names []string) http.Header {
+	h = h.Clone()
+	for _, name := range names {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, redacted)
+		}
+	}
+	return h
+}
+
+// peek reads at most maxBodySize bytes from r.
+func (c *logConfig) peek(r io.Reader) []byte {
+	data, _ := io.ReadAll(io.LimitReader(r, int64(c.maxBodySize)))
+	return data
+}
+
+// peekResponse reads the head of body and returns a body which still yields all the data.
+func (c *logConfig) peekResponse(body io.ReadCloser) ([]byte, io.ReadCloser) {
+	head := c.peek(body)
+	return head, struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), body), body}
+}
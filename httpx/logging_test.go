@@ -0,0 +1,77 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// stubDoer returns a middleware which responds with the given status and body without sending the request.
+func stubDoer(status int, body string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Set-Cookie": {"session=secret"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		})
+	}
+}
+
+func TestLogging(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	resp := NewRequest(context.Background(), http.MethodPost, "http://localhost/users").
+		JSONBody(User{Name: "Tom"}).
+		BearerToken("token").
+		Use(Logging(zap.New(core), WithLogBody(8)), stubDoer(http.StatusNotFound, "not found")).
+		Do()
+	require.NoError(t, resp.err)
+	// the response body is intact
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "not found", string(data))
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	fields := entry.ContextMap()
+	assert.Equal(t, "POST", fields["method"])
+	assert.Equal(t, "http://localhost/users", fields["url"])
+	assert.Equal(t, int64(http.StatusNotFound), fields["status"])
+	assert.Equal(t, `{"Name":`, fields["request_body"])
+	assert.Equal(t, "not foun", fields["response_body"])
+	assert.Equal(t, redacted, fields["request_header"].(http.Header).Get("Authorization"))
+	assert.Equal(t, redacted, fields["response_header"].(http.Header).Get("Set-Cookie"))
+}
+
+func TestRequest_Hooks(t *testing.T) {
+	var (
+		method  string
+		status  int
+		elapsed time.Duration
+	)
+	resp := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		OnRequest(func(req *http.Request) {
+			method = req.Method
+		}).
+		OnResponse(func(req *http.Request, resp *http.Response, err error, d time.Duration) {
+			status = resp.StatusCode
+			elapsed = d
+		}).
+		Use(stubDoer(http.StatusOK, "")).
+		Do()
+	require.NoError(t, resp.err)
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Greater(t, elapsed, time.Duration(0))
+}
@@ -0,0 +1,88 @@
+package httpx
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// Metrics collects the Prometheus metrics of outbound requests,
+// labeled by method, host and status class, e.g. "2xx", or "error" if the request failed.
+// It is a prometheus.Collector, register it once and share its Middleware among requests.
+type Metrics struct {
+	namespace string
+	buckets   []float64
+
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// WithMetricsNamespace sets the namespace of the metric names.
+func WithMetricsNamespace(namespace string) option.Option[Metrics] {
+	return func(m *Metrics) {
+		m.namespace = namespace
+	}
+}
+
+// WithLatencyBuckets sets the buckets of the latency histogram in seconds.
+func WithLatencyBuckets(buckets ...float64) option.Option[Metrics] {
+	return func(m *Metrics) {
+		m.buckets = buckets
+	}
+}
+
+// NewMetrics returns a Metrics exposing httpx_client_requests_total
+// and httpx_client_request_duration_seconds.
+func NewMetrics(opts ...option.Option[Metrics]) *Metrics {
+	m := &Metrics{buckets: prometheus.DefBuckets}
+	option.Apply(m, opts...)
+
+	labels := []string{"method", "host", "status_class"}
+	m.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Subsystem: "httpx_client",
+		Name:      "requests_total",
+		Help:      "Total number of outbound HTTP requests.",
+	}, labels)
+	m.latency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Subsystem: "httpx_client",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of outbound HTTP requests.",
+		Buckets:   m.buckets,
+	}, labels)
+	return m
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.latency.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.latency.Collect(ch)
+}
+
+// Middleware returns a middleware recording every request.
+func (m *Metrics) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			class := "error"
+			if err == nil {
+				class = strconv.Itoa(resp.StatusCode/100) + "xx"
+			}
+			labels := prometheus.Labels{"method": req.Method, "host": req.URL.Host, "status_class": class}
+			m.requests.With(labels).Inc()
+			m.latency.With(labels).Observe(time.Since(start).Seconds())
+			return resp, err
+		})
+	}
+}
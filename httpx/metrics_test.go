@@ -0,0 +1,37 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics(WithMetricsNamespace("test"))
+	reg := prometheus.NewRegistry()
+	assert.NoError(t, reg.Register(m))
+
+	for i := 0; i < 2; i++ {
+		NewRequest(context.Background(), http.MethodGet, "http://localhost/users").
+			Use(m.Middleware(), stubDoer(http.StatusOK, "")).
+			Do()
+	}
+	NewRequest(context.Background(), http.MethodPost, "http://localhost/users").
+		Use(m.Middleware(), func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("mock error")
+			})
+		}).
+		Do()
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues(http.MethodGet, "localhost", "2xx")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues(http.MethodPost, "localhost", "error")))
+	cnt, err := testutil.GatherAndCount(reg, "test_httpx_client_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cnt)
+}
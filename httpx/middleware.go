@@ -0,0 +1,34 @@
+package httpx
+
+import "net/http"
+
+// Doer sends an HTTP request and returns its response, *http.Client is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add cross-cutting concerns such as logging, metrics or auth.
+type Middleware func(next Doer) Doer
+
+// Use appends middlewares to the request.
+// The first one is the outermost, and every retry attempt goes through all of them.
+func (r *Request) Use(mws ...Middleware) *Request {
+	r.middlewares = append(r.middlewares, mws...)
+	return r
+}
+
+// doer returns the client wrapped by the middlewares.
+func (r *Request) doer() Doer {
+	var d Doer = r.client
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		d = r.middlewares[i](d)
+	}
+	return d
+}
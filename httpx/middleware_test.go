@@ -0,0 +1,37 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Use(t *testing.T) {
+	var calls []string
+	mw := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next.Do(req)
+				calls = append(calls, name+" after")
+				return resp, err
+			})
+		}
+	}
+	stub := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, "do")
+			return &http.Response{StatusCode: http.StatusNoContent}, nil
+		})
+	}
+
+	resp := NewRequest(context.Background(), http.MethodGet, "http://localhost").
+		Use(mw("first"), mw("second")).
+		Use(stub).
+		Do()
+	assert.NoError(t, resp.err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, []string{"first before", "second before", "do", "second after", "first after"}, calls)
+}
@@ -0,0 +1,82 @@
+package httpx
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/ecloudclub/zkit/iox"
+)
+
+// Body uses body as req.Body. Like http.NewRequest, the body can be sent again on retries
+// if it is a *bytes.Buffer, *bytes.Reader or *strings.Reader.
+func (r *Request) Body(body io.Reader) *Request {
+	if r.err != nil {
+		return r
+	}
+	switch b := body.(type) {
+	case *bytes.Buffer:
+		r.setBody(b.Bytes())
+	case *bytes.Reader:
+		snapshot := *b
+		r.req.ContentLength = int64(b.Len())
+		r.req.Body = io.NopCloser(b)
+		r.req.GetBody = func() (io.ReadCloser, error) {
+			cp := snapshot
+			return io.NopCloser(&cp), nil
+		}
+	case *strings.Reader:
+		snapshot := *b
+		r.req.ContentLength = int64(b.Len())
+		r.req.Body = io.NopCloser(b)
+		r.req.GetBody = func() (io.ReadCloser, error) {
+			cp := snapshot
+			return io.NopCloser(&cp), nil
+		}
+	default:
+		rc, ok := body.(io.ReadCloser)
+		if !ok {
+			rc = io.NopCloser(body)
+		}
+		r.req.Body = rc
+		r.req.GetBody = nil
+	}
+	return r
+}
+
+// OnProgress reports the upload progress of the body with the number of bytes sent so far
+// and the total size, which is -1 if unknown. It is reported again from 0 on retries.
+// Like iox.ProgressReader, fn is called at most every 100ms and once more when the body is fully sent.
+func (r *Request) OnProgress(fn func(sent, total int64)) *Request {
+	r.progress = fn
+	return r
+}
+
+// trackProgress wraps the body, and the ones for retries, to report the upload progress.
+func (r *Request) trackProgress() {
+	if r.progress == nil || r.req.Body == nil {
+		return
+	}
+	total := r.req.ContentLength
+	if total == 0 {
+		total = -1
+	}
+	r.req.Body = progressBody(r.req.Body, total, r.progress)
+	if getBody := r.req.GetBody; getBody != nil {
+		r.req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return progressBody(body, total, r.progress), nil
+		}
+	}
+}
+
+// progressBody reports the progress of reading rc while keeping it closable.
+func progressBody(rc io.ReadCloser, total int64, fn func(sent, total int64)) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{iox.ProgressReader(rc, total, fn), rc}
+}
@@ -0,0 +1,63 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_OnProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	payload := strings.Repeat("a", 100<<10)
+	testCases := []struct {
+		name      string
+		body      io.Reader
+		wantTotal int64
+	}{
+		{
+			name:      "strings reader",
+			body:      strings.NewReader(payload),
+			wantTotal: int64(len(payload)),
+		},
+		{
+			name:      "bytes buffer",
+			body:      bytes.NewBufferString(payload),
+			wantTotal: int64(len(payload)),
+		},
+		{
+			name:      "unknown size",
+			body:      io.MultiReader(strings.NewReader(payload)),
+			wantTotal: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent, total int64
+			resp := NewRequest(context.Background(), http.MethodPost, server.URL).
+				Body(tc.body).
+				OnProgress(func(s, tt int64) {
+					sent, total = s, tt
+				}).
+				Do()
+			require.NoError(t, resp.err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, len(payload), len(body))
+			assert.Equal(t, int64(len(payload)), sent)
+			assert.Equal(t, tc.wantTotal, total)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// ErrRateLimited is returned without sending the request when the rate limit is exceeded in fail-fast mode.
+var ErrRateLimited = errors.New("zkit: 请求超出限流")
+
+type rateLimiter struct {
+	rps      float64
+	burst    int
+	perHost  bool
+	failFast bool
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// WithPerHost limits every host separately instead of all the requests together.
+func WithPerHost() option.Option[rateLimiter] {
+	return func(l *rateLimiter) {
+		l.perHost = true
+	}
+}
+
+// WithFailFast makes requests exceeding the rate limit fail with ErrRateLimited instead of waiting.
+func WithFailFast() option.Option[rateLimiter] {
+	return func(l *rateLimiter) {
+		l.failFast = true
+	}
+}
+
+// RateLimit returns a middleware limiting requests to rps per second with bursts of at most burst requests.
+// By default, requests wait for their turn until their context is done.
+func RateLimit(rps float64, burst int, opts ...option.Option[rateLimiter]) Middleware {
+	l := &rateLimiter{
+		rps:      rps,
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+	option.Apply(l, opts...)
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			limiter := l.limiter(req.URL.Host)
+			if l.failFast {
+				if !limiter.Allow() {
+					return nil, ErrRateLimited
+				}
+			} else if err := limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+func (l *rateLimiter) limiter(host string) *rate.Limiter {
+	if !l.perHost {
+		host = ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limiter, ok := l.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.rps), l.burst)
+		l.limiters[host] = limiter
+	}
+	return limiter
+}
+
+// WithRateLimit limits the requests of the client, see RateLimit.
+func WithRateLimit(rps float64, burst int, opts ...option.Option[rateLimiter]) option.Option[Client] {
+	return WithMiddlewares(RateLimit(rps, burst, opts...))
+}
@@ -0,0 +1,30 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	cli := NewClient(WithRateLimit(20, 1), WithMiddlewares(stubDoer(http.StatusOK, "")))
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, cli.Get(context.Background(), "http://localhost").Do().err)
+	}
+	// the first one is sent immediately, the rest wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	mw := RateLimit(1, 1, WithFailFast(), WithPerHost())
+	do := func(url string) error {
+		return NewRequest(context.Background(), http.MethodGet, url).
+			Use(mw, stubDoer(http.StatusOK, "")).Do().err
+	}
+	assert.NoError(t, do("http://localhost"))
+	assert.Equal(t, ErrRateLimited, do("http://localhost"))
+	// other hosts have their own limit
+	assert.NoError(t, do("http://example.com"))
+}
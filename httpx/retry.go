@@ -0,0 +1,167 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+const (
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	defaultRetryMaxDelay  = 5 * time.Second
+)
+
+// RetryOption configures the retries of a Request, see Request.Retry.
+type RetryOption = option.Option[retryPolicy]
+
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	retryable  func(resp *http.Response, err error) bool
+}
+
+// WithRetryBackoff sets the delay before the first retry, which doubles on every retry up to maxDelay.
+func WithRetryBackoff(baseDelay, maxDelay time.Duration) RetryOption {
+	return func(p *retryPolicy) {
+		p.baseDelay = baseDelay
+		p.maxDelay = maxDelay
+	}
+}
+
+// WithRetryIf sets the predicate deciding whether a request should be retried.
+// By default, network errors and 429, 502, 503 and 504 responses are retried.
+func WithRetryIf(retryable func(resp *http.Response, err error) bool) RetryOption {
+	return func(p *retryPolicy) {
+		p.retryable = retryable
+	}
+}
+
+// WithRetryStatus retries the requests which fail with a network error or one of the given status codes.
+func WithRetryStatus(codes ...int) RetryOption {
+	return WithRetryIf(func(resp *http.Response, err error) bool {
+		if err != nil {
+			return retryableErr(err)
+		}
+		for _, code := range codes {
+			if resp.StatusCode == code {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Retry retries the request up to n times with exponential backoff and jitter
+// when it fails transiently. A Retry-After header in the response takes precedence over the backoff.
+// The body is rebuilt for every attempt, which requires http.Request.GetBody,
+// it is set by JSONBody, XMLBody and ProtoBody.
+func (r *Request) Retry(n int, opts ...RetryOption) *Request {
+	p := &retryPolicy{
+		maxRetries: n,
+		baseDelay:  defaultRetryBaseDelay,
+		maxDelay:   defaultRetryMaxDelay,
+		retryable:  defaultRetryable,
+	}
+	option.Apply(p, opts...)
+	r.retry = p
+	return r
+}
+
+func defaultRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return retryableErr(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryableErr reports whether err is not caused by the context of the request.
+func retryableErr(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// doWithRetry sends the request, retrying it according to the retry policy.
+func (r *Request) doWithRetry(doer Doer) (*http.Response, error) {
+	p := r.retry
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && r.req.GetBody != nil {
+			body, err := r.req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.req.Body = body
+		}
+
+		resp, err := doer.Do(r.req)
+		if attempt >= p.maxRetries || !p.retryable(resp, err) {
+			return resp, err
+		}
+		// The body has been consumed and can't be sent again
+		if r.req.Body != nil && r.req.GetBody == nil {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				delay = d
+			}
+			// Drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.req.Context().Done():
+			timer.Stop()
+			return nil, r.req.Context().Err()
+		}
+	}
+}
+
+// backoff returns the delay before the retry following the given attempt,
+// which is randomized in [d/2, d) to avoid retrying in lockstep with other clients.
+func (p *retryPolicy) backoff(attempt int) time.Duration {
+	d := p.baseDelay << attempt
+	if d <= 0 || d > p.maxDelay {
+		d = p.maxDelay
+	}
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + rand.N(d-half)
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	val := resp.Header.Get("Retry-After")
+	if val == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(val); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(val); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -0,0 +1,111 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_Retry(t *testing.T) {
+	var cnt int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var u User
+		if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if atomic.AddInt32(&cnt, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(u)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name       string
+		retries    int
+		opts       []RetryOption
+		wantStatus int
+		wantCnt    int32
+	}{
+		{
+			name:       "succeed after retries",
+			retries:    3,
+			wantStatus: http.StatusOK,
+			wantCnt:    3,
+		},
+		{
+			name:       "retries exhausted",
+			retries:    1,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCnt:    2,
+		},
+		{
+			name:    "not retryable",
+			retries: 3,
+			opts: []RetryOption{
+				WithRetryStatus(http.StatusBadGateway),
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCnt:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			atomic.StoreInt32(&cnt, 0)
+			resp := NewRequest(context.Background(), http.MethodPost, server.URL).
+				JSONBody(User{Name: "Tom"}).
+				Retry(tc.retries, tc.opts...).
+				Do()
+			require.NoError(t, resp.err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.Equal(t, tc.wantCnt, atomic.LoadInt32(&cnt))
+			if tc.wantStatus == http.StatusOK {
+				var u User
+				assert.NoError(t, resp.JSONReceive(&u))
+				assert.Equal(t, "Tom", u.Name)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &retryPolicy{baseDelay: 100 * time.Millisecond, maxDelay: time.Second}
+	for attempt, want := range []time.Duration{
+		100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		800 * time.Millisecond, time.Second, time.Second,
+	} {
+		d := p.backoff(attempt)
+		assert.GreaterOrEqual(t, d, want/2)
+		assert.Less(t, d, want)
+	}
+	// never overflows
+	assert.LessOrEqual(t, p.backoff(100), time.Second)
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	_, ok := retryAfter(resp)
+	assert.False(t, ok)
+
+	resp.Header.Set("Retry-After", "2")
+	d, ok := retryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, d)
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	d, ok = retryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+}
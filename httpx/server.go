@@ -2,18 +2,250 @@ package httpx
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ecloudclub/zkit/option"
+	"google.golang.org/protobuf/proto"
 )
 
 type Response struct {
 	*http.Response
 	err error
+	// body is the buffered body once buffered is true, see Bytes
+	body        []byte
+	buffered    bool
+	maxBodySize int64
+	timing      *timingRecorder
 }
 
 func (r *Response) JSONReceive(val any) error {
 	if r.err != nil {
 		return r.err
 	}
-	err := json.NewDecoder(r.Body).Decode(&val)
+	err := json.NewDecoder(r.reader()).Decode(&val)
 	return err
 }
+
+func (r *Response) XMLReceive(val any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return xml.NewDecoder(r.reader()).Decode(val)
+}
+
+func (r *Response) ProtoReceive(m proto.Message) error {
+	if r.err != nil {
+		return r.err
+	}
+	data, err := io.ReadAll(r.reader())
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, m)
+}
+
+// Into decodes the body into val according to the Content-Type of the response,
+// which may be XML or protobuf, and defaults to JSON.
+func (r *Response) Into(val any) error {
+	if r.err != nil {
+		return r.err
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/x-protobuf":
+		if m, ok := val.(proto.Message); ok {
+			return r.ProtoReceive(m)
+		}
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return r.XMLReceive(val)
+	}
+	return r.JSONReceive(val)
+}
+
+// DecodeJSON decodes the JSON body of resp into a T.
+func DecodeJSON[T any](resp *Response) (T, error) {
+	var val T
+	err := resp.JSONReceive(&val)
+	return val, err
+}
+
+// ErrorEnvelope converts err into the status code and the body of an error response.
+type ErrorEnvelope func(err error) (code int, body any)
+
+// StatusError is an error to respond with the given status code and message.
+type StatusError struct {
+	Code    int
+	Message string
+	// Err is the cause, which is never exposed to the client
+	Err error
+}
+
+// NewStatusError returns a *StatusError, msg defaults to the status text of code.
+func NewStatusError(code int, msg string) *StatusError {
+	if msg == "" {
+		msg = http.StatusText(code)
+	}
+	return &StatusError{Code: code, Message: msg}
+}
+
+func (e *StatusError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("zkit: HTTP %d %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("zkit: HTTP %d %s", e.Code, e.Message)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorBody is the body written by the default ErrorEnvelope.
+type ErrorBody struct {
+	Code    int    `json:"code" xml:"code"`
+	Message string `json:"message" xml:"message"`
+}
+
+// defaultErrorEnvelope uses the code and message of *StatusError,
+// other errors are reported as 500 without their details.
+func defaultErrorEnvelope(err error) (int, any) {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code, ErrorBody{Code: se.Code, Message: se.Message}
+	}
+	code := http.StatusInternalServerError
+	return code, ErrorBody{Code: code, Message: http.StatusText(code)}
+}
+
+// Responder writes responses of the same shape for the handlers.
+type Responder struct {
+	errorEnvelope ErrorEnvelope
+}
+
+func NewResponder(opts ...option.Option[Responder]) *Responder {
+	res := &Responder{errorEnvelope: defaultErrorEnvelope}
+	option.Apply(res, opts...)
+	return res
+}
+
+// WithErrorEnvelope customizes the error responses of WriteError.
+func WithErrorEnvelope(fn ErrorEnvelope) option.Option[Responder] {
+	return func(r *Responder) {
+		r.errorEnvelope = fn
+	}
+}
+
+var defaultResponder = NewResponder()
+
+// WriteJSON writes v as JSON with the status code.
+// v is encoded before anything is written, so an encoding error can still be responded.
+func (res *Responder) WriteJSON(w http.ResponseWriter, code int, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeBody(w, code, "application/json; charset=utf-8", data)
+}
+
+// WriteError writes err as JSON through the ErrorEnvelope.
+func (res *Responder) WriteError(w http.ResponseWriter, err error) error {
+	code, body := res.errorEnvelope(err)
+	return res.WriteJSON(w, code, body)
+}
+
+// Write writes v in the format accepted by the request, which may be JSON, XML or protobuf.
+// JSON is used if the Accept header is missing or none of them is acceptable.
+func (res *Responder) Write(w http.ResponseWriter, r *http.Request, code int, v any) error {
+	var (
+		data []byte
+		err  error
+	)
+	contentType := negotiate(r.Header.Get("Accept"), v)
+	switch contentType {
+	case "application/x-protobuf":
+		data, err = proto.Marshal(v.(proto.Message))
+	case "application/xml":
+		data, err = xml.Marshal(v)
+		contentType += "; charset=utf-8"
+	default:
+		return res.WriteJSON(w, code, v)
+	}
+	if err != nil {
+		return err
+	}
+	return writeBody(w, code, contentType, data)
+}
+
+// NoContent writes a 204 response.
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WriteJSON writes v as JSON with the status code, see Responder.WriteJSON.
+func WriteJSON(w http.ResponseWriter, code int, v any) error {
+	return defaultResponder.WriteJSON(w, code, v)
+}
+
+// WriteError writes err with the default error envelope, see Responder.WriteError.
+func WriteError(w http.ResponseWriter, err error) error {
+	return defaultResponder.WriteError(w, err)
+}
+
+// Write writes v in the format accepted by r, see Responder.Write.
+func Write(w http.ResponseWriter, r *http.Request, code int, v any) error {
+	return defaultResponder.Write(w, r, code, v)
+}
+
+func writeBody(w http.ResponseWriter, code int, contentType string, data []byte) error {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(code)
+	_, err := w.Write(data)
+	return err
+}
+
+// negotiate returns the media type of the response according to the Accept header,
+// preferring the one with the highest q value and then the first one.
+func negotiate(accept string, v any) string {
+	const jsonType = "application/json"
+	var (
+		best  = jsonType
+		bestQ = 0.0
+	)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+		var candidate string
+		switch mediaType {
+		case jsonType, "application/*", "*/*":
+			candidate = jsonType
+		case "application/xml", "text/xml":
+			candidate = "application/xml"
+		case "application/x-protobuf":
+			if _, ok := v.(proto.Message); ok {
+				candidate = mediaType
+			}
+		}
+		if candidate != "" {
+			best, bestQ = candidate, q
+		}
+	}
+	return best
+}
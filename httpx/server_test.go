@@ -0,0 +1,186 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestResponse_Into(t *testing.T) {
+	data, err := proto.Marshal(wrapperspb.String("Tom"))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+		val         any
+		want        any
+	}{
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"Name":"Tom"}`,
+			val:         &User{},
+			want:        &User{Name: "Tom"},
+		},
+		{
+			name: "default json",
+			body: `{"Name":"Tom"}`,
+			val:  &User{},
+			want: &User{Name: "Tom"},
+		},
+		{
+			name:        "xml",
+			contentType: "text/xml",
+			body:        "<User><Name>Tom</Name></User>",
+			val:         &User{},
+			want:        &User{Name: "Tom"},
+		},
+		{
+			name:        "protobuf",
+			contentType: "application/x-protobuf",
+			body:        string(data),
+			val:         &wrapperspb.StringValue{},
+			want:        "Tom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := newBodyResponse(tc.body)
+			resp.Header = map[string][]string{"Content-Type": {tc.contentType}}
+			assert.NoError(t, resp.Into(tc.val))
+			if m, ok := tc.val.(*wrapperspb.StringValue); ok {
+				assert.Equal(t, tc.want, m.GetValue())
+				return
+			}
+			assert.Equal(t, tc.want, tc.val)
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	u, err := DecodeJSON[User](newBodyResponse(`{"Name":"Tom"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, User{Name: "Tom"}, u)
+
+	_, err = DecodeJSON[User](&Response{err: errors.New("mock error")})
+	assert.Equal(t, errors.New("mock error"), err)
+}
+
+func TestWriteError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		res      *Responder
+		err      error
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "status error",
+			res:      defaultResponder,
+			err:      fmt.Errorf("wrap: %w", NewStatusError(http.StatusNotFound, "")),
+			wantCode: http.StatusNotFound,
+			wantBody: `{"code":404,"message":"Not Found"}`,
+		},
+		{
+			name:     "internal error",
+			res:      defaultResponder,
+			err:      errors.New("db is down"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: `{"code":500,"message":"Internal Server Error"}`,
+		},
+		{
+			name: "custom envelope",
+			res: NewResponder(WithErrorEnvelope(func(err error) (int, any) {
+				return http.StatusBadRequest, map[string]string{"error": err.Error()}
+			})),
+			err:      errors.New("bad"),
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"bad"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			assert.NoError(t, tc.res.WriteError(rec, tc.err))
+			assert.Equal(t, tc.wantCode, rec.Code)
+			assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+			assert.JSONEq(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	assert.NoError(t, WriteJSON(rec, http.StatusCreated, User{Name: "Tom"}))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"Name":"Tom"}`, rec.Body.String())
+
+	// nothing is written if v cannot be encoded
+	rec = httptest.NewRecorder()
+	assert.Error(t, WriteJSON(rec, http.StatusOK, make(chan int)))
+	assert.False(t, rec.Flushed)
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+
+	rec = httptest.NewRecorder()
+	NoContent(rec)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestWrite(t *testing.T) {
+	testCases := []struct {
+		name     string
+		accept   string
+		val      any
+		wantType string
+	}{
+		{
+			name:     "no accept",
+			val:      User{Name: "Tom"},
+			wantType: "application/json; charset=utf-8",
+		},
+		{
+			name:     "xml",
+			accept:   "text/html, application/xml;q=0.9, */*;q=0.8",
+			val:      User{Name: "Tom"},
+			wantType: "application/xml; charset=utf-8",
+		},
+		{
+			name:     "prefer json",
+			accept:   "application/xml;q=0.5, application/json",
+			val:      User{Name: "Tom"},
+			wantType: "application/json; charset=utf-8",
+		},
+		{
+			name:     "protobuf",
+			accept:   "application/x-protobuf",
+			val:      wrapperspb.String("Tom"),
+			wantType: "application/x-protobuf",
+		},
+		{
+			name:     "not a proto message",
+			accept:   "application/x-protobuf",
+			val:      User{Name: "Tom"},
+			wantType: "application/json; charset=utf-8",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tc.accept)
+			rec := httptest.NewRecorder()
+			assert.NoError(t, Write(rec, req, http.StatusOK, tc.val))
+			assert.Equal(t, tc.wantType, rec.Header().Get("Content-Type"))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing is the latency breakdown of a request collected by httptrace, see Request.TraceTiming.
+// The phases of a reused connection are zero. With retries, it is the one of the last attempt.
+type Timing struct {
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	// TTFB is the time from getting a connection to the first byte of the response,
+	// including the phases above
+	TTFB time.Duration
+	// ConnReused reports whether the connection was reused from the pool
+	ConnReused bool
+}
+
+// TraceTiming collects the Timing of the request, which is returned by Response.Timing.
+func (r *Request) TraceTiming() *Request {
+	r.timing = &timingRecorder{}
+	return r
+}
+
+// Timing returns the latency breakdown of the request if Request.TraceTiming was called.
+func (r *Response) Timing() Timing {
+	if r.timing == nil {
+		return Timing{}
+	}
+	return r.timing.get()
+}
+
+// timingRecorder records the timing from the callbacks of httptrace,
+// some of which are called by the dialing goroutine.
+type timingRecorder struct {
+	mu sync.Mutex
+	Timing
+	start, dnsStart, connectStart, tlsStart time.Time
+}
+
+func (t *timingRecorder) get() Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.Timing
+}
+
+func (t *timingRecorder) record(fn func()) {
+	t.mu.Lock()
+	fn()
+	t.mu.Unlock()
+}
+
+func (t *timingRecorder) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.record(func() {
+				// Starts over on every attempt
+				t.Timing = Timing{}
+				t.start = time.Now()
+			})
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.record(func() { t.ConnReused = info.Reused })
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.record(func() { t.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.record(func() { t.DNS = time.Since(t.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			t.record(func() { t.connectStart = time.Now() })
+		},
+		ConnectDone: func(string, string, error) {
+			t.record(func() { t.Connect = time.Since(t.connectStart) })
+		},
+		TLSHandshakeStart: func() {
+			t.record(func() { t.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.record(func() { t.TLS = time.Since(t.tlsStart) })
+		},
+		GotFirstResponseByte: func() {
+			t.record(func() { t.TTFB = time.Since(t.start) })
+		},
+	}
+}
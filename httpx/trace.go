@@ -0,0 +1,51 @@
+package httpx
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ecloudclub/zkit/httpx"
+
+// Tracing returns a middleware creating a client span for every request,
+// whose parent is the span in the context of the request.
+// The span context is injected into the request headers as W3C traceparent,
+// and the status code, the response size and the error are recorded on the span.
+func Tracing(tp trace.TracerProvider) Middleware {
+	tracer := tp.Tracer(instrumentationName)
+	propagator := propagation.TraceContext{}
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context(), "HTTP "+req.Method,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("http.request.method", req.Method),
+					attribute.String("url.full", req.URL.Redacted()),
+					attribute.String("server.address", req.URL.Host),
+				))
+			defer span.End()
+
+			req = req.Clone(ctx)
+			propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			resp, err := next.Do(req)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+			if resp.ContentLength >= 0 {
+				span.SetAttributes(attribute.Int64("http.response.body.size", resp.ContentLength))
+			}
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+			}
+			return resp, nil
+		})
+	}
+}
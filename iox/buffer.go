@@ -0,0 +1,58 @@
+package iox
+
+import (
+	"bytes"
+	"sync"
+)
+
+// bufferClasses are the capacities of the size classes of pooled buffers.
+var bufferClasses = [...]int{512, 4 << 10, 32 << 10, 256 << 10}
+
+// maxBufferSize is the maximum capacity of buffers kept in the pools,
+// larger ones are left to the GC so that a single huge payload does not pin memory.
+const maxBufferSize = 1 << 20
+
+// bufferPools[i] holds buffers whose capacity is in [bufferClasses[i], bufferClasses[i+1]).
+var bufferPools [len(bufferClasses)]sync.Pool
+
+// GetBuffer returns an empty buffer from the pools, which should be put back by PutBuffer.
+func GetBuffer() *bytes.Buffer {
+	return GetBufferSize(0)
+}
+
+// GetBufferSize returns an empty buffer whose capacity is at least size if size is not
+// larger than the largest size class.
+func GetBufferSize(size int) *bytes.Buffer {
+	for i := classOf(size); i < len(bufferClasses); i++ {
+		if buf, ok := bufferPools[i].Get().(*bytes.Buffer); ok {
+			buf.Reset()
+			return buf
+		}
+	}
+	buf := new(bytes.Buffer)
+	buf.Grow(max(size, bufferClasses[0]))
+	return buf
+}
+
+// PutBuffer puts buf back to the pool of its size class, buf must not be used after that.
+func PutBuffer(buf *bytes.Buffer) {
+	c := buf.Cap()
+	if c < bufferClasses[0] || c > maxBufferSize {
+		return
+	}
+	i := len(bufferClasses) - 1
+	for i > 0 && c < bufferClasses[i] {
+		i--
+	}
+	bufferPools[i].Put(buf)
+}
+
+// classOf returns the smallest size class which can hold size bytes.
+func classOf(size int) int {
+	for i, c := range bufferClasses {
+		if size <= c {
+			return i
+		}
+	}
+	return len(bufferClasses)
+}
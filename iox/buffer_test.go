@@ -0,0 +1,58 @@
+package iox
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer(t *testing.T) {
+	buf := GetBuffer()
+	assert.Equal(t, 0, buf.Len())
+	assert.GreaterOrEqual(t, buf.Cap(), 512)
+	buf.WriteString("hello")
+	PutBuffer(buf)
+
+	// the buffers are reset
+	buf = GetBuffer()
+	assert.Equal(t, 0, buf.Len())
+	PutBuffer(buf)
+
+	buf = GetBufferSize(10 << 10)
+	assert.GreaterOrEqual(t, buf.Cap(), 10<<10)
+	PutBuffer(buf)
+
+	// larger than the largest size class
+	buf = GetBufferSize(512 << 10)
+	assert.GreaterOrEqual(t, buf.Cap(), 512<<10)
+	PutBuffer(buf)
+
+	// too large to be kept
+	PutBuffer(bytes.NewBuffer(make([]byte, 0, 2<<20)))
+	// too small to be kept
+	PutBuffer(bytes.NewBuffer(make([]byte, 0, 10)))
+}
+
+func TestClassOf(t *testing.T) {
+	testCases := map[int]int{
+		0:         0,
+		512:       0,
+		513:       1,
+		4 << 10:   1,
+		100 << 10: 3,
+		1 << 20:   4,
+	}
+	for size, want := range testCases {
+		assert.Equal(t, want, classOf(size), size)
+	}
+}
+
+func BenchmarkBuffer(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := GetBuffer()
+		buf.WriteString("hello")
+		PutBuffer(buf)
+	}
+}
@@ -0,0 +1,60 @@
+package iox
+
+import (
+	"errors"
+	"io"
+)
+
+// MultiCloser returns a Closer that closes all closers in reverse order, like defers,
+// and returns the errors of them joined by errors.Join. Nil closers are skipped.
+func MultiCloser(closers ...io.Closer) io.Closer {
+	return multiCloser(closers)
+}
+
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+	var errs []error
+	for i := len(m) - 1; i >= 0; i-- {
+		if m[i] == nil {
+			continue
+		}
+		if err := m[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CloseIgnoring closes c and ignores the error if it matches any of ignored by errors.Is,
+// e.g. os.ErrClosed or net.ErrClosed for a closer which may have been closed.
+func CloseIgnoring(c io.Closer, ignored ...error) error {
+	err := c.Close()
+	for _, target := range ignored {
+		if errors.Is(err, target) {
+			return nil
+		}
+	}
+	return err
+}
+
+// DeferClose closes c and joins its error into *errp, which is usually a named return:
+//
+//	func write(path string) (err error) {
+//		f, err := os.Create(path)
+//		if err != nil {
+//			return err
+//		}
+//		defer iox.DeferClose(&err, f)
+//		...
+//	}
+func DeferClose(errp *error, c io.Closer) {
+	err := c.Close()
+	switch {
+	case err == nil:
+	case *errp == nil:
+		*errp = err
+	default:
+		*errp = errors.Join(*errp, err)
+	}
+}
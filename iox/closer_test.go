@@ -0,0 +1,61 @@
+package iox
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCloser struct {
+	err    error
+	closed *[]string
+	name   string
+}
+
+func (m mockCloser) Close() error {
+	*m.closed = append(*m.closed, m.name)
+	return m.err
+}
+
+func TestMultiCloser(t *testing.T) {
+	var closed []string
+	err1, err2 := errors.New("err1"), errors.New("err2")
+	c := MultiCloser(
+		mockCloser{name: "a", err: err1, closed: &closed},
+		nil,
+		mockCloser{name: "b", closed: &closed},
+		mockCloser{name: "c", err: err2, closed: &closed},
+	)
+	err := c.Close()
+	assert.Equal(t, []string{"c", "b", "a"}, closed)
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+
+	assert.NoError(t, MultiCloser().Close())
+}
+
+func TestCloseIgnoring(t *testing.T) {
+	var closed []string
+	assert.NoError(t, CloseIgnoring(mockCloser{err: os.ErrClosed, closed: &closed}, os.ErrClosed))
+	assert.Equal(t, os.ErrClosed, CloseIgnoring(mockCloser{err: os.ErrClosed, closed: &closed}))
+	assert.NoError(t, CloseIgnoring(mockCloser{closed: &closed}, os.ErrClosed))
+}
+
+func TestDeferClose(t *testing.T) {
+	var closed []string
+	closeErr := errors.New("close error")
+	fn := func(retErr error, c mockCloser) (err error) {
+		defer DeferClose(&err, c)
+		return retErr
+	}
+
+	assert.NoError(t, fn(nil, mockCloser{closed: &closed}))
+	assert.Equal(t, closeErr, fn(nil, mockCloser{err: closeErr, closed: &closed}))
+
+	retErr := errors.New("return error")
+	err := fn(retErr, mockCloser{err: closeErr, closed: &closed})
+	assert.ErrorIs(t, err, retErr)
+	assert.ErrorIs(t, err, closeErr)
+}
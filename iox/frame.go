@@ -0,0 +1,102 @@
+package iox
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+)
+
+var (
+	ErrFrameTooLarge    = errors.New("zkit: 帧的长度超过限制")
+	ErrInvalidChunkSize = errors.New("zkit: 分块的长度必须大于 0")
+)
+
+// ChunkReader returns an iterator of the frames of size bytes read from r,
+// the last one of which may be shorter. Reading errors other than EOF are yielded with a nil frame.
+// The frame is only valid until the next iteration, since its buffer is reused.
+// If size is not positive, ErrInvalidChunkSize is yielded without reading r.
+func ChunkReader(r io.Reader, size int) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		if size <= 0 {
+			yield(nil, ErrInvalidChunkSize)
+			return
+		}
+		buf := make([]byte, size)
+		for {
+			n, err := io.ReadFull(r, buf)
+			switch {
+			case err == nil:
+				if !yield(buf, nil) {
+					return
+				}
+			case errors.Is(err, io.ErrUnexpectedEOF):
+				yield(buf[:n], nil)
+				return
+			case errors.Is(err, io.EOF):
+				return
+			default:
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// FrameWriter writes frames prefixed with their length in 4 bytes of big endian.
+type FrameWriter struct {
+	w io.Writer
+}
+
+func NewFrameWriter(w io.Writer) *FrameWriter {
+	return &FrameWriter{w: w}
+}
+
+// WriteFrame writes p as a frame.
+func (fw *FrameWriter) WriteFrame(p []byte) error {
+	if uint64(len(p)) > math.MaxUint32 {
+		return ErrFrameTooLarge
+	}
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(p)))
+	if _, err := fw.w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := fw.w.Write(p)
+	return err
+}
+
+// FrameReader reads the frames written by FrameWriter.
+type FrameReader struct {
+	r       io.Reader
+	maxSize int
+}
+
+// NewFrameReader returns a FrameReader which rejects frames larger than maxSize bytes,
+// so that a corrupted or malicious length does not make it allocate unbounded memory.
+func NewFrameReader(r io.Reader, maxSize int) *FrameReader {
+	return &FrameReader{r: r, maxSize: maxSize}
+}
+
+// ReadFrame reads the next frame, it returns io.EOF if there are no more frames,
+// and io.ErrUnexpectedEOF if the last frame is incomplete.
+func (fr *FrameReader) ReadFrame() ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(fr.r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if uint64(size) > uint64(fr.maxSize) {
+		return nil, fmt.Errorf("%w: %d > %d", ErrFrameTooLarge, size, fr.maxSize)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(fr.r, frame); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return frame, nil
+}
@@ -0,0 +1,115 @@
+package iox
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkReader(t *testing.T) {
+	testCases := []struct {
+		name    string
+		r       io.Reader
+		size    int
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "short last frame",
+			r:    strings.NewReader("hello world"),
+			size: 4,
+			want: []string{"hell", "o wo", "rld"},
+		},
+		{
+			name: "exact frames",
+			r:    iotest.OneByteReader(strings.NewReader("abcdef")),
+			size: 3,
+			want: []string{"abc", "def"},
+		},
+		{
+			name: "empty",
+			r:    strings.NewReader(""),
+			size: 3,
+		},
+		{
+			name:    "error",
+			r:       io.MultiReader(strings.NewReader("abcd"), iotest.ErrReader(iotest.ErrTimeout)),
+			size:    3,
+			want:    []string{"abc"},
+			wantErr: iotest.ErrTimeout,
+		},
+		{
+			name:    "zero size",
+			r:       strings.NewReader("abc"),
+			size:    0,
+			wantErr: ErrInvalidChunkSize,
+		},
+		{
+			name:    "negative size",
+			r:       strings.NewReader("abc"),
+			size:    -1,
+			wantErr: ErrInvalidChunkSize,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				got []string
+				err error
+			)
+			for frame, e := range ChunkReader(tc.r, tc.size) {
+				if e != nil {
+					err = e
+					continue
+				}
+				got = append(got, string(frame))
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+
+	// stops early
+	var got []string
+	for frame := range ChunkReader(strings.NewReader("abcdef"), 2) {
+		got = append(got, string(frame))
+		break
+	}
+	assert.Equal(t, []string{"ab"}, got)
+}
+
+func TestFrame(t *testing.T) {
+	var buf bytes.Buffer
+	fw := NewFrameWriter(&buf)
+	frames := []string{"hello", "", "world"}
+	for _, f := range frames {
+		require.NoError(t, fw.WriteFrame([]byte(f)))
+	}
+
+	fr := NewFrameReader(bytes.NewReader(buf.Bytes()), 5)
+	for _, f := range frames {
+		frame, err := fr.ReadFrame()
+		require.NoError(t, err)
+		assert.Equal(t, f, string(frame))
+	}
+	_, err := fr.ReadFrame()
+	assert.Equal(t, io.EOF, err)
+
+	// too large
+	_, err = NewFrameReader(bytes.NewReader(buf.Bytes()), 4).ReadFrame()
+	assert.ErrorIs(t, err, ErrFrameTooLarge)
+
+	// incomplete
+	_, err = NewFrameReader(bytes.NewReader(buf.Bytes()[:6]), 5).ReadFrame()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	_, err = NewFrameReader(bytes.NewReader(buf.Bytes()[:4]), 5).ReadFrame()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	_, err = NewFrameReader(bytes.NewReader(buf.Bytes()[:2]), 5).ReadFrame()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
@@ -0,0 +1,96 @@
+package iox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+var (
+	gzipWriterPool = sync.Pool{
+		New: func() any {
+			return gzip.NewWriter(nil)
+		},
+	}
+	gzipReaderPool sync.Pool
+)
+
+// GzipReader returns a reader of val marshaled into JSON and compressed with gzip,
+// e.g. as a body with Content-Encoding: gzip.
+// Both are done on the first read, with a pooled gzip writer.
+func GzipReader(val any) io.Reader {
+	return &gzipReader{val: val}
+}
+
+type gzipReader struct {
+	val any
+	buf *bytes.Reader
+}
+
+func (g *gzipReader) Read(p []byte) (int, error) {
+	if g.buf == nil {
+		data, err := gzipJSON(g.val)
+		if err != nil {
+			return 0, err
+		}
+		g.buf = bytes.NewReader(data)
+	}
+	return g.buf.Read(p)
+}
+
+func gzipJSON(val any) ([]byte, error) {
+	jr := GetJSONReader(val)
+	defer PutJSONReader(jr)
+
+	var buf bytes.Buffer
+	zw := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(zw)
+	zw.Reset(&buf)
+	if _, err := io.Copy(zw, jr); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GunzipReader returns a reader of the data decompressed from r, with a pooled gzip reader
+// which is put back when it is closed. It does not close r.
+func GunzipReader(r io.Reader) (io.ReadCloser, error) {
+	zr, ok := gzipReaderPool.Get().(*gzip.Reader)
+	if !ok {
+		var err error
+		if zr, err = gzip.NewReader(r); err != nil {
+			return nil, err
+		}
+		return &gunzipReader{zr: zr}, nil
+	}
+	if err := zr.Reset(r); err != nil {
+		gzipReaderPool.Put(zr)
+		return nil, err
+	}
+	return &gunzipReader{zr: zr}, nil
+}
+
+type gunzipReader struct {
+	zr *gzip.Reader
+}
+
+func (g *gunzipReader) Read(p []byte) (int, error) {
+	if g.zr == nil {
+		return 0, io.ErrClosedPipe
+	}
+	return g.zr.Read(p)
+}
+
+func (g *gunzipReader) Close() error {
+	if g.zr == nil {
+		return nil
+	}
+	err := g.zr.Close()
+	gzipReaderPool.Put(g.zr)
+	g.zr = nil
+	return err
+}
@@ -0,0 +1,50 @@
+package iox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzip(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		// the pooled gzip readers and writers are reused
+		r, err := GunzipReader(GzipReader(User{Name: "Tom"}))
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"Tom"}`, string(data))
+		assert.NoError(t, r.Close())
+		assert.NoError(t, r.Close())
+		_, err = r.Read(make([]byte, 1))
+		assert.Error(t, err)
+	}
+
+	// compatible with compress/gzip
+	zr, err := gzip.NewReader(GzipReader([]int{1, 2}))
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(data))
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte("hello"))
+	require.NoError(t, zw.Close())
+	r, err := GunzipReader(&buf)
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	require.NoError(t, r.Close())
+
+	_, err = GunzipReader(strings.NewReader("not gzip"))
+	assert.Error(t, err)
+	_, err = io.ReadAll(GzipReader(make(chan int)))
+	assert.Error(t, err)
+}
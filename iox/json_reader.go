@@ -1,14 +1,20 @@
 package iox
 
 import (
-	"bytes"
+	"io"
+	"sync"
 
-	"github.com/bytedance/sonic"
+	"github.com/bytedance/sonic/encoder"
 )
 
+// maxPooledJSONSize avoids keeping the large buffers of JSONReader in the pool.
+const maxPooledJSONSize = 64 << 10
+
 type JSONReader struct {
-	val any
-	buf *bytes.Reader
+	val     any
+	data    []byte
+	off     int
+	encoded bool
 }
 
 // NewJSONReader is used to solve the scenario of serializing a structure into JSON and then wrapping it into io.Reader.
@@ -24,16 +30,48 @@ func NewJSONReader(val any) *JSONReader {
 }
 
 func (r *JSONReader) Read(obj []byte) (n int, err error) {
-	if r.buf == nil {
-		var data []byte
-		data, err = sonic.Marshal(r.val)
-		if err == nil {
-			r.buf = bytes.NewReader(data)
+	if !r.encoded {
+		// Reuses the buffer of the previous value after Reset
+		r.data = r.data[:0]
+		if err = encoder.EncodeInto(&r.data, r.val, encoder.NoEncoderNewline); err != nil {
+			return 0, err
 		}
+		r.encoded = true
 	}
-	if err != nil {
-		return
+	if r.off >= len(r.data) {
+		return 0, io.EOF
 	}
+	n = copy(obj, r.data[r.off:])
+	r.off += n
+	return n, nil
+}
 
-	return r.buf.Read(obj)
+// Reset makes r read val from the beginning, reusing its buffer.
+func (r *JSONReader) Reset(val any) {
+	r.val = val
+	r.off = 0
+	r.encoded = false
+}
+
+var jsonReaderPool = sync.Pool{
+	New: func() any {
+		return &JSONReader{}
+	},
+}
+
+// GetJSONReader returns a JSONReader of val from the pool, which should be put back by PutJSONReader.
+func GetJSONReader(val any) *JSONReader {
+	r := jsonReaderPool.Get().(*JSONReader)
+	r.Reset(val)
+	return r
+}
+
+// PutJSONReader puts r back to the pool, r must not be used after that.
+func PutJSONReader(r *JSONReader) {
+	if cap(r.data) > maxPooledJSONSize {
+		return
+	}
+	// Don't keep the value alive
+	r.Reset(nil)
+	jsonReaderPool.Put(r)
 }
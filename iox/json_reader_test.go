@@ -1,6 +1,7 @@
 package iox
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +46,45 @@ func TestJSONReader(t *testing.T) {
 type User struct {
 	Name string `json:"name"`
 }
+
+func TestJSONReader_Reset(t *testing.T) {
+	r := GetJSONReader(User{Name: "Tom"})
+	data, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Tom"}`, string(data))
+
+	r.Reset(User{Name: "Jerry"})
+	data, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Jerry"}`, string(data))
+	PutJSONReader(r)
+
+	r = GetJSONReader([]int{1, 2})
+	data, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(data))
+
+	// the error of encoding
+	r.Reset(make(chan int))
+	_, err = io.ReadAll(r)
+	assert.Error(t, err)
+	PutJSONReader(r)
+}
+
+func BenchmarkJSONReader(b *testing.B) {
+	u := User{Name: "Tom"}
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = io.Copy(io.Discard, NewJSONReader(u))
+		}
+	})
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := GetJSONReader(u)
+			_, _ = io.Copy(io.Discard, r)
+			PutJSONReader(r)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package iox
+
+import (
+	"io"
+
+	"github.com/bytedance/sonic/encoder"
+)
+
+// JSONStreamReader reads the items returned by an iterator as a JSON array,
+// marshaling one item at a time as it is read, see NewJSONStreamReader.
+type JSONStreamReader struct {
+	iter func() (any, bool)
+	// buf holds the encoded data of the current item
+	buf   []byte
+	off   int
+	count int
+	done  bool
+	err   error
+}
+
+// NewJSONStreamReader returns a reader of the JSON array of the items returned by iter,
+// which returns false when there are no more items.
+// Only one item is kept in memory, so that very large result sets can be sent as a body
+// without materializing the whole payload. Non-thread-safe.
+func NewJSONStreamReader(iter func() (any, bool)) *JSONStreamReader {
+	return &JSONStreamReader{iter: iter}
+}
+
+func (r *JSONStreamReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if r.off >= len(r.buf) {
+			if r.err != nil {
+				break
+			}
+			if r.done {
+				r.err = io.EOF
+				break
+			}
+			r.next()
+			continue
+		}
+		c := copy(p[n:], r.buf[r.off:])
+		r.off += c
+		n += c
+	}
+	if n > 0 {
+		return n, nil
+	}
+	return 0, r.err
+}
+
+// next encodes the next item into buf, with the brackets and separators of the array.
+func (r *JSONStreamReader) next() {
+	r.buf = r.buf[:0]
+	r.off = 0
+	if r.count == 0 {
+		r.buf = append(r.buf, '[')
+	}
+	val, ok := r.iter()
+	if !ok {
+		r.buf = append(r.buf, ']')
+		r.done = true
+		return
+	}
+	if r.count > 0 {
+		r.buf = append(r.buf, ',')
+	}
+	r.count++
+	if err := encoder.EncodeInto(&r.buf, val, encoder.NoEncoderNewline); err != nil {
+		// Drops the partial data of the item
+		r.buf = r.buf[:0]
+		r.err = err
+	}
+}
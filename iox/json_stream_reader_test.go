@@ -0,0 +1,80 @@
+package iox
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceIter returns an iterator of vals.
+func sliceIter(vals ...any) func() (any, bool) {
+	i := 0
+	return func() (any, bool) {
+		if i >= len(vals) {
+			return nil, false
+		}
+		i++
+		return vals[i-1], true
+	}
+}
+
+func TestJSONStreamReader(t *testing.T) {
+	testCases := []struct {
+		name    string
+		iter    func() (any, bool)
+		bufSize int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			iter:    sliceIter(),
+			bufSize: 512,
+			want:    `[]`,
+		},
+		{
+			name:    "items",
+			iter:    sliceIter(User{Name: "Tom"}, User{Name: "Jerry"}, nil),
+			bufSize: 512,
+			want:    `[{"name":"Tom"},{"name":"Jerry"},null]`,
+		},
+		{
+			name:    "small buffer",
+			iter:    sliceIter(User{Name: "Tom"}, 1),
+			bufSize: 3,
+			want:    `[{"name":"Tom"},1]`,
+		},
+		{
+			name:    "encoding error",
+			iter:    sliceIter(1, make(chan int)),
+			bufSize: 512,
+			want:    `[1`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewJSONStreamReader(tc.iter)
+			var got []byte
+			buf := make([]byte, tc.bufSize)
+			var err error
+			for {
+				var n int
+				n, err = r.Read(buf)
+				got = append(got, buf[:n]...)
+				if err != nil {
+					break
+				}
+			}
+			assert.Equal(t, tc.want, string(got))
+			if tc.wantErr {
+				assert.False(t, errors.Is(err, io.EOF))
+				return
+			}
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package iox
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrLimitExceeded = errors.New("zkit: 读取的数据超过限制")
+
+// LimitReader returns a reader that reads at most n bytes from r.
+// Unlike io.LimitReader, which silently stops at n bytes, it returns ErrLimitExceeded
+// if r has more data, so that a truncated body is never mistaken for a complete one.
+func LimitReader(r io.Reader, n int64) io.Reader {
+	return &limitReader{r: r, n: n}
+}
+
+type limitReader struct {
+	r io.Reader
+	// n is the number of bytes remaining
+	n int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if l.n <= 0 {
+		return 0, l.probe()
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// probe reads one more byte after the limit is reached to find out if r has more data.
+func (l *limitReader) probe() error {
+	var b [1]byte
+	// Like bufio, gives up if r keeps returning no data and no error
+	for i := 0; i < 100; i++ {
+		n, err := l.r.Read(b[:])
+		if n > 0 {
+			return ErrLimitExceeded
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return io.ErrNoProgress
+}
@@ -0,0 +1,66 @@
+package iox
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitReader(t *testing.T) {
+	testCases := []struct {
+		name    string
+		r       io.Reader
+		n       int64
+		want    string
+		wantErr error
+	}{
+		{
+			name: "under the limit",
+			r:    strings.NewReader("hello"),
+			n:    10,
+			want: "hello",
+		},
+		{
+			name: "exactly the limit",
+			r:    strings.NewReader("hello"),
+			n:    5,
+			want: "hello",
+		},
+		{
+			name: "exactly the limit, one byte at a time",
+			r:    iotest.OneByteReader(strings.NewReader("hello")),
+			n:    5,
+			want: "hello",
+		},
+		{
+			name:    "exceeded",
+			r:       strings.NewReader("hello world"),
+			n:       5,
+			want:    "hello",
+			wantErr: ErrLimitExceeded,
+		},
+		{
+			name:    "zero limit",
+			r:       strings.NewReader("hello"),
+			n:       0,
+			wantErr: ErrLimitExceeded,
+		},
+		{
+			name:    "error of the underlying reader",
+			r:       iotest.ErrReader(iotest.ErrTimeout),
+			n:       5,
+			wantErr: iotest.ErrTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := io.ReadAll(LimitReader(tc.r, tc.n))
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, string(data))
+		})
+	}
+}
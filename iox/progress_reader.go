@@ -0,0 +1,48 @@
+package iox
+
+import (
+	"io"
+	"time"
+)
+
+// progressInterval is the minimum interval between two callbacks of ProgressReader.
+const progressInterval = 100 * time.Millisecond
+
+// ProgressReader returns a reader that reports the number of bytes read so far and total,
+// which is -1 if unknown, to cb. To keep progress bars cheap, cb is called at most every 100ms,
+// and always once more when r reaches EOF or fails, so that the final count is never missed.
+func ProgressReader(r io.Reader, total int64, cb func(read, total int64)) io.Reader {
+	return &progressReader{r: r, total: total, cb: cb}
+}
+
+type progressReader struct {
+	r        io.Reader
+	total    int64
+	cb       func(read, total int64)
+	read     int64
+	reported int64
+	last     time.Time
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if err != nil {
+		if p.read != p.reported || p.last.IsZero() {
+			p.report(time.Now())
+		}
+		return n, err
+	}
+	if n > 0 {
+		if now := time.Now(); now.Sub(p.last) >= progressInterval {
+			p.report(now)
+		}
+	}
+	return n, nil
+}
+
+func (p *progressReader) report(now time.Time) {
+	p.last = now
+	p.reported = p.read
+	p.cb(p.read, p.total)
+}
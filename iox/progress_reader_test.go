@@ -0,0 +1,47 @@
+package iox
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressReader(t *testing.T) {
+	var calls [][2]int64
+	cb := func(read, total int64) {
+		calls = append(calls, [2]int64{read, total})
+	}
+
+	// reads one byte at a time, the callbacks are throttled
+	r := ProgressReader(iotest.OneByteReader(strings.NewReader("hello")), 5, cb)
+	data, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, [][2]int64{{1, 5}, {5, 5}}, calls)
+
+	// slow reads are all reported
+	calls = nil
+	r = ProgressReader(&slowReader{r: strings.NewReader("abc")}, -1, cb)
+	_, err = io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int64{{1, -1}, {2, -1}, {3, -1}}, calls)
+
+	// empty reader
+	calls = nil
+	_, err = io.ReadAll(ProgressReader(strings.NewReader(""), 0, cb))
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int64{{0, 0}}, calls)
+}
+
+type slowReader struct {
+	r io.Reader
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(progressInterval + 10*time.Millisecond)
+	return s.r.Read(p[:1])
+}
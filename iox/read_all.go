@@ -0,0 +1,38 @@
+package iox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// LimitError is returned by ReadAll if the data is larger than the limit,
+// errors.Is(err, ErrLimitExceeded) reports true for it.
+type LimitError struct {
+	// Limit is the maximum size passed to ReadAll
+	Limit int64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %d 字节", ErrLimitExceeded, e.Limit)
+}
+
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// ReadAll reads from r until EOF like io.ReadAll, but fails with *LimitError if r has more than
+// maxSize bytes, so it is a safer default for untrusted inputs.
+// It reads into pooled buffers and returns the data with the number of bytes read,
+// the data read before an error is returned as well except when the limit is exceeded.
+func ReadAll(r io.Reader, maxSize int64) ([]byte, int64, error) {
+	buf := GetBufferSize(int(min(maxSize, int64(bufferClasses[len(bufferClasses)-1]))))
+	defer PutBuffer(buf)
+
+	n, err := buf.ReadFrom(LimitReader(r, maxSize))
+	if errors.Is(err, ErrLimitExceeded) {
+		return nil, n, &LimitError{Limit: maxSize}
+	}
+	return bytes.Clone(buf.Bytes()), n, err
+}
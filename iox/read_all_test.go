@@ -0,0 +1,80 @@
+package iox
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadAll(t *testing.T) {
+	testCases := []struct {
+		name     string
+		r        io.Reader
+		maxSize  int64
+		wantData string
+		wantN    int64
+		wantErr  error
+	}{
+		{
+			name:     "within limit",
+			r:        strings.NewReader("hello"),
+			maxSize:  10,
+			wantData: "hello",
+			wantN:    5,
+		},
+		{
+			name:     "exactly limit",
+			r:        iotest.OneByteReader(strings.NewReader("hello")),
+			maxSize:  5,
+			wantData: "hello",
+			wantN:    5,
+		},
+		{
+			name:     "empty",
+			r:        strings.NewReader(""),
+			maxSize:  5,
+			wantData: "",
+		},
+		{
+			name:    "exceeded",
+			r:       strings.NewReader("hello world"),
+			maxSize: 5,
+			wantN:   5,
+			wantErr: &LimitError{Limit: 5},
+		},
+		{
+			name:     "error",
+			r:        io.MultiReader(strings.NewReader("hel"), iotest.ErrReader(iotest.ErrTimeout)),
+			maxSize:  5,
+			wantData: "hel",
+			wantN:    3,
+			wantErr:  iotest.ErrTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, n, err := ReadAll(tc.r, tc.maxSize)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantN, n)
+			if tc.wantErr == nil || tc.wantData != "" {
+				assert.Equal(t, tc.wantData, string(data))
+			}
+		})
+	}
+
+	_, _, err := ReadAll(strings.NewReader("hello world"), 5)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, "zkit: 读取的数据超过限制: 5 字节", err.Error())
+
+	// the data does not share memory with the pooled buffers
+	data, _, err := ReadAll(strings.NewReader("hello"), 10)
+	assert.NoError(t, err)
+	other, _, err := ReadAll(strings.NewReader("world"), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, "world", string(other))
+}
@@ -0,0 +1,43 @@
+package loadbalance
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoAvailableNode 没有可以选择的节点
+var ErrNoAvailableNode = errors.New("zkit: 没有可用的节点")
+
+// Node 后端节点
+type Node struct {
+	Addr string
+	// Weight 节点权重，只对加权的策略有效，小于 1 时按 1 处理
+	Weight int
+}
+
+// Balancer 负载均衡器，所有实现都是并发安全的
+type Balancer interface {
+	// Pick 为一次请求选择节点，key 供基于哈希的策略使用，其余策略会忽略它。
+	// 请求结束后必须调用 done 并传入请求的错误，以便统计节点的负载
+	Pick(ctx context.Context, key string) (node Node, done func(err error), err error)
+	// Update 将节点整体替换为 nodes
+	Update(nodes []Node)
+}
+
+// Tracker 由统计节点负载的 Balancer 实现，用于统计没有经过 Pick 选择节点的请求，
+// 例如 Sticky 按会话直接选择的节点，使负载数据依然准确。
+// 返回的 done 与 Pick 返回的相同，节点不存在时什么也不做
+type Tracker interface {
+	Track(addr string) (done func(err error))
+}
+
+// track 在 b 实现了 Tracker 时统计请求，包装其它 Balancer 的策略用它转发 Track
+func track(b Balancer, addr string) func(err error) {
+	if t, ok := b.(Tracker); ok {
+		return t.Track(addr)
+	}
+	return noopDone
+}
+
+// noopDone 是不需要统计请求结果的策略返回的 done
+func noopDone(error) {}
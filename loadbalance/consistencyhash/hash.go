@@ -1,107 +1,217 @@
 package consistencyhash
 
 import (
-	"crypto/md5"
 	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
+
+	"github.com/ecloudclub/zkit/option"
 )
 
 type ConsistentHash struct {
-	replicas int             // 虚拟节点倍数
-	keys     []int           // 哈希环
-	hashMap  map[int]string  // 虚拟节点到真实节点的映射
-	nodes    map[string]bool // 真实节点集合
-	mu       sync.RWMutex    // 读写锁
+	replicas int                  // 虚拟节点倍数
+	ring     atomic.Pointer[ring] // 哈希环，变更时整体替换，查找时无需加锁
+	nodes    map[string]int       // 真实节点及其权重
+	hashFunc HashFunc             // 哈希函数
+	mu       sync.RWMutex         // 保护节点变更
+}
+
+// ring 哈希环，创建后不再修改
+type ring struct {
+	keys    []uint64          // 排序后的虚拟节点
+	hashMap map[uint64]string // 虚拟节点到真实节点的映射
+}
+
+// Node 批量变更时使用的节点及其权重
+type Node struct {
+	Name   string
+	Weight int
 }
 
 // NewConsistentHash 创建一个新的ConsistentHash实例
-func NewConsistentHash(replicas int) *ConsistentHash {
-	return &ConsistentHash{
+func NewConsistentHash(replicas int, opts ...option.Option[ConsistentHash]) *ConsistentHash {
+	c := &ConsistentHash{
 		replicas: replicas,
-		hashMap:  make(map[int]string),
-		nodes:    make(map[string]bool),
+		nodes:    make(map[string]int),
+		hashFunc: MD5,
 	}
+	option.Apply(c, opts...)
+	c.ring.Store(&ring{hashMap: make(map[uint64]string)})
+	return c
 }
 
-// AddNode 添加节点到哈希环
+// AddNode 添加权重为 1 的节点到哈希环
 func (c *ConsistentHash) AddNode(node string) {
+	c.AddNodeWithWeight(node, 1)
+}
+
+// AddNodeWithWeight 添加带权重的节点到哈希环，节点的虚拟节点数为 replicas * weight，
+// 权重小于 1 时按 1 处理
+func (c *ConsistentHash) AddNodeWithWeight(node string, weight int) {
+	c.AddNodes(Node{Name: node, Weight: weight})
+}
+
+// AddNodes 批量添加节点，已存在的节点会被忽略，只重建一次哈希环
+func (c *ConsistentHash) AddNodes(nodes ...Node) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if _, ok := c.nodes[node]; ok {
-		return // 节点已存在
-	}
+	c.rebuild(func(hashMap map[uint64]string) {
+		for _, node := range nodes {
+			if _, ok := c.nodes[node.Name]; ok {
+				continue // 节点已存在
+			}
+			weight := max(node.Weight, 1)
+			c.nodes[node.Name] = weight
+			c.addVirtualNodes(hashMap, node.Name, 0, c.replicas*weight)
+		}
+	})
+}
 
-	c.nodes[node] = true
+// UpdateWeight 调整节点的权重，只增删差额部分的虚拟节点，节点不存在时返回 false
+func (c *ConsistentHash) UpdateWeight(node string, weight int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	// 为每个真实节点创建replicas个虚拟节点
-	for i := 0; i < c.replicas; i++ {
-		virtualNode := node + "#" + strconv.Itoa(i)
-		hash := int(c.hash(virtualNode))
-		c.keys = append(c.keys, hash)
-		c.hashMap[hash] = node
+	old, ok := c.nodes[node]
+	if !ok {
+		return false
 	}
 
-	// 重新排序哈希环
-	sort.Ints(c.keys)
+	weight = max(weight, 1)
+	c.nodes[node] = weight
+	c.rebuild(func(hashMap map[uint64]string) {
+		if weight > old {
+			c.addVirtualNodes(hashMap, node, c.replicas*old, c.replicas*weight)
+		} else {
+			c.removeVirtualNodes(hashMap, node, c.replicas*weight, c.replicas*old)
+		}
+	})
+	return true
 }
 
 // RemoveNode 从哈希环中移除节点
 func (c *ConsistentHash) RemoveNode(node string) {
+	c.RemoveNodes(node)
+}
+
+// RemoveNodes 批量移除节点，不存在的节点会被忽略，只重建一次哈希环
+func (c *ConsistentHash) RemoveNodes(nodes ...string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if _, ok := c.nodes[node]; !ok {
-		return // 节点不存在
+	c.rebuild(func(hashMap map[uint64]string) {
+		for _, node := range nodes {
+			weight, ok := c.nodes[node]
+			if !ok {
+				continue // 节点不存在
+			}
+			delete(c.nodes, node)
+			c.removeVirtualNodes(hashMap, node, 0, c.replicas*weight)
+		}
+	})
+}
+
+// SetNodes 将节点整体替换为 nodes，只增删变化的虚拟节点并重建一次哈希环，
+// 查找在替换前后看到的都是完整的哈希环
+func (c *ConsistentHash) SetNodes(nodes []Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	latest := make(map[string]int, len(nodes))
+	for _, node := range nodes {
+		latest[node.Name] = max(node.Weight, 1)
+	}
+	c.rebuild(func(hashMap map[uint64]string) {
+		for node, old := range c.nodes {
+			weight, ok := latest[node]
+			switch {
+			case !ok:
+				c.removeVirtualNodes(hashMap, node, 0, c.replicas*old)
+			case weight < old:
+				c.removeVirtualNodes(hashMap, node, c.replicas*weight, c.replicas*old)
+			}
+		}
+		for node, weight := range latest {
+			old := c.nodes[node]
+			if weight > old {
+				c.addVirtualNodes(hashMap, node, c.replicas*old, c.replicas*weight)
+			}
+		}
+	})
+	c.nodes = latest
+}
+
+// rebuild 在哈希环的副本上执行变更，排序后整体替换，调用方需要持有锁
+func (c *ConsistentHash) rebuild(change func(hashMap map[uint64]string)) {
+	old := c.ring.Load()
+	hashMap := make(map[uint64]string, len(old.hashMap))
+	for hash, node := range old.hashMap {
+		hashMap[hash] = node
 	}
+	change(hashMap)
 
-	delete(c.nodes, node)
+	r := &ring{
+		keys:    make([]uint64, 0, len(hashMap)),
+		hashMap: hashMap,
+	}
+	for hash := range hashMap {
+		r.keys = append(r.keys, hash)
+	}
+	// 重新排序哈希环
+	sort.Slice(r.keys, func(i, j int) bool {
+		return r.keys[i] < r.keys[j]
+	})
+	c.ring.Store(r)
+}
 
-	// 移除所有虚拟节点
-	for i := 0; i < c.replicas; i++ {
+// addVirtualNodes 添加节点编号为 [from, to) 的虚拟节点
+func (c *ConsistentHash) addVirtualNodes(hashMap map[uint64]string, node string, from, to int) {
+	for i := from; i < to; i++ {
 		virtualNode := node + "#" + strconv.Itoa(i)
-		hash := int(c.hash(virtualNode))
+		hashMap[c.hash(virtualNode)] = node
+	}
+}
 
-		// 从keys中删除
-		index := sort.SearchInts(c.keys, hash)
-		if index < len(c.keys) && c.keys[index] == hash {
-			c.keys = append(c.keys[:index], c.keys[index+1:]...)
+// removeVirtualNodes 移除节点编号为 [from, to) 的虚拟节点
+func (c *ConsistentHash) removeVirtualNodes(hashMap map[uint64]string, node string, from, to int) {
+	for i := from; i < to; i++ {
+		virtualNode := node + "#" + strconv.Itoa(i)
+		hash := c.hash(virtualNode)
+		// 哈希冲突时虚拟节点可能已经属于其他节点
+		if hashMap[hash] == node {
+			delete(hashMap, hash)
 		}
-
-		// 从hashMap中删除
-		delete(c.hashMap, hash)
 	}
 }
 
 // GetNode 获取key对应的节点
 func (c *ConsistentHash) GetNode(key string) string {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	if len(c.keys) == 0 {
+	r := c.ring.Load()
+	if len(r.keys) == 0 {
 		return ""
 	}
 
-	hash := int(c.hash(key))
-
 	// 使用二分查找找到第一个大于等于hash的节点
-	idx := sort.Search(len(c.keys), func(i int) bool {
-		return c.keys[i] >= hash
-	})
+	idx := r.search(c.hash(key))
 
 	// 如果没找到大于等于的节点，则使用第一个节点（环形结构）
-	if idx == len(c.keys) {
+	if idx == len(r.keys) {
 		idx = 0
 	}
 
-	return c.hashMap[c.keys[idx]]
+	return r.hashMap[r.keys[idx]]
+}
+
+// search 返回哈希环上第一个大于等于hash的位置
+func (r *ring) search(hash uint64) int {
+	return sort.Search(len(r.keys), func(i int) bool {
+		return r.keys[i] >= hash
+	})
 }
 
-// hash 计算字符串的哈希值（使用MD5）
-func (c *ConsistentHash) hash(key string) uint32 {
-	h := md5.New()
-	h.Write([]byte(key))
-	hash := h.Sum(nil)
-	return uint32(hash[0])<<24 | uint32(hash[1])<<16 | uint32(hash[2])<<8 | uint32(hash[3])
+// hash 计算字符串的哈希值
+func (c *ConsistentHash) hash(key string) uint64 {
+	return c.hashFunc([]byte(key))
 }
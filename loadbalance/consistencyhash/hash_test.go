@@ -1,7 +1,10 @@
 package consistencyhash
 
 import (
+	"math"
+	"reflect"
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -92,3 +95,226 @@ func TestSingleNode(t *testing.T) {
 		}
 	}
 }
+
+func TestHashFunc(t *testing.T) {
+	// MurmurHash3 的标准测试向量
+	murmur3Cases := map[string]uint64{
+		"":      0,
+		"hello": 0x248bfa47,
+		"The quick brown fox jumps over the lazy dog": 0x2e4ff723,
+	}
+	for data, want := range murmur3Cases {
+		if got := Murmur3([]byte(data)); got != want {
+			t.Errorf("Murmur3(%q) = %#x, want %#x", data, got, want)
+		}
+	}
+
+	hashFuncs := map[string]HashFunc{
+		"md5":     MD5,
+		"crc32":   CRC32,
+		"xxhash":  XXHash,
+		"murmur3": Murmur3,
+	}
+	for name, fn := range hashFuncs {
+		t.Run(name, func(t *testing.T) {
+			ch := NewConsistentHash(100, WithHashFunc(fn))
+			nodes := map[string]int{"Node1": 0, "Node2": 0, "Node3": 0}
+			for node := range nodes {
+				ch.AddNode(node)
+			}
+			for i := 0; i < 3000; i++ {
+				key := "key" + strconv.Itoa(i)
+				node := ch.GetNode(key)
+				nodes[node]++
+				if ch.GetNode(key) != node {
+					t.Fatalf("Key %s is not assigned to the same node", key)
+				}
+			}
+			// 每个节点都应该分到一部分key
+			for node, cnt := range nodes {
+				if cnt < 500 {
+					t.Errorf("Node %s got only %d keys", node, cnt)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedNodes(t *testing.T) {
+	ch := NewConsistentHash(50, WithHashFunc(XXHash))
+	ch.AddNodeWithWeight("Node1", 1)
+	ch.AddNodeWithWeight("Node2", 3)
+
+	count := func() map[string]int {
+		cnt := make(map[string]int)
+		for i := 0; i < 4000; i++ {
+			cnt[ch.GetNode("key"+strconv.Itoa(i))]++
+		}
+		return cnt
+	}
+
+	// 权重为 3 的节点应该分到大约 3 倍的key
+	cnt := count()
+	if ratio := float64(cnt["Node2"]) / float64(cnt["Node1"]); ratio < 2 || ratio > 4.5 {
+		t.Errorf("Expected Node2 to get about 3 times the keys of Node1, got %v", cnt)
+	}
+
+	if ch.UpdateWeight("NotExist", 2) {
+		t.Error("Expected UpdateWeight to fail for a missing node")
+	}
+	if !ch.UpdateWeight("Node2", 1) {
+		t.Error("Expected UpdateWeight to succeed")
+	}
+	if len(ch.ring.Load().keys) != 100 || len(ch.ring.Load().hashMap) != 100 {
+		t.Errorf("Expected 100 virtual nodes, got %d", len(ch.ring.Load().keys))
+	}
+	cnt = count()
+	if ratio := float64(cnt["Node2"]) / float64(cnt["Node1"]); ratio < 0.6 || ratio > 1.6 {
+		t.Errorf("Expected the nodes to get about the same keys, got %v", cnt)
+	}
+
+	// 与直接以新权重添加的节点分布相同
+	expected := NewConsistentHash(50, WithHashFunc(XXHash))
+	expected.AddNode("Node1")
+	expected.AddNode("Node2")
+	for i := 0; i < 100; i++ {
+		key := "key" + strconv.Itoa(i)
+		if ch.GetNode(key) != expected.GetNode(key) {
+			t.Errorf("Key %s is assigned differently after UpdateWeight", key)
+		}
+	}
+
+	ch.RemoveNode("Node2")
+	if len(ch.ring.Load().keys) != 50 {
+		t.Errorf("Expected 50 virtual nodes, got %d", len(ch.ring.Load().keys))
+	}
+}
+
+func TestStats(t *testing.T) {
+	if stats := NewConsistentHash(10).Stats(); len(stats.Nodes) != 0 {
+		t.Errorf("Expected no stats for empty ring, got %v", stats)
+	}
+
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+	var sparseStdDev float64
+	// 虚拟节点越多分布越均匀
+	for i, replicas := range []int{1, 10, 500} {
+		for _, fn := range []HashFunc{MD5, XXHash} {
+			ch := NewConsistentHash(replicas, WithHashFunc(fn))
+			ch.AddNode("Node1")
+			ch.AddNodeWithWeight("Node2", 2)
+			ch.AddNode("Node3")
+
+			stats := ch.Stats()
+			var total float64
+			for _, ns := range stats.Nodes {
+				total += ns.Share
+				if ns.VirtualNodes != replicas*ns.Weight {
+					t.Errorf("Expected %d virtual nodes, got %d", replicas*ns.Weight, ns.VirtualNodes)
+				}
+			}
+			if math.Abs(total-1) > 1e-9 {
+				t.Errorf("Expected the shares to sum to 1, got %v", total)
+			}
+			if stats.Nodes[1].Node != "Node2" || stats.Nodes[1].Weight != 2 {
+				t.Errorf("Expected the nodes to be sorted, got %v", stats.Nodes)
+			}
+
+			// 实际的 key 分布与份额相近
+			cnt := ch.Simulate(keys)
+			for _, ns := range stats.Nodes {
+				if got := float64(cnt[ns.Node]) / float64(len(keys)); math.Abs(got-ns.Share) > 0.05 {
+					t.Errorf("Expected %s to get about %.2f of the keys, got %.2f", ns.Node, ns.Share, got)
+				}
+			}
+
+			if i == 2 && stats.StdDev > 0.1 {
+				t.Errorf("Expected a small standard deviation with %d replicas, got %v", replicas, stats.StdDev)
+			}
+			if i == 0 {
+				sparseStdDev = max(sparseStdDev, stats.StdDev)
+			}
+		}
+	}
+	if sparseStdDev < 0.1 {
+		t.Errorf("Expected a large standard deviation with 1 replica, got %v", sparseStdDev)
+	}
+}
+
+func TestBatchUpdate(t *testing.T) {
+	ch := NewConsistentHash(20, WithHashFunc(XXHash))
+	ch.AddNodes(Node{Name: "Node1"}, Node{Name: "Node2", Weight: 2}, Node{Name: "Node1", Weight: 5})
+
+	// 与逐个添加得到相同的哈希环
+	expected := NewConsistentHash(20, WithHashFunc(XXHash))
+	expected.AddNode("Node1")
+	expected.AddNodeWithWeight("Node2", 2)
+	if !reflect.DeepEqual(ch.ring.Load(), expected.ring.Load()) {
+		t.Error("Expected the same ring as adding the nodes one by one")
+	}
+
+	ch.SetNodes([]Node{{Name: "Node2", Weight: 1}, {Name: "Node3", Weight: 3}})
+	expected = NewConsistentHash(20, WithHashFunc(XXHash))
+	expected.AddNodes(Node{Name: "Node2"}, Node{Name: "Node3", Weight: 3})
+	if !reflect.DeepEqual(ch.ring.Load(), expected.ring.Load()) {
+		t.Error("Expected the same ring as adding the nodes to an empty ring")
+	}
+	if !reflect.DeepEqual(ch.nodes, map[string]int{"Node2": 1, "Node3": 3}) {
+		t.Errorf("Unexpected nodes %v", ch.nodes)
+	}
+
+	ch.RemoveNodes("Node2", "Node3", "NotExist")
+	if node := ch.GetNode("key"); node != "" {
+		t.Errorf("Expected empty node for empty ring, got %s", node)
+	}
+}
+
+func TestConcurrentUpdate(t *testing.T) {
+	ch := NewConsistentHash(20)
+	ch.SetNodes([]Node{{Name: "Node1"}, {Name: "Node2"}})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			ch.SetNodes([]Node{{Name: "Node1"}, {Name: "Node" + strconv.Itoa(i%3+2)}})
+		}
+	}()
+	// 查找时总能看到完整的哈希环
+	for i := 0; i < 1000; i++ {
+		if node := ch.GetNode("key" + strconv.Itoa(i)); node == "" {
+			t.Fatal("Expected a node during updates")
+		}
+	}
+	wg.Wait()
+}
+
+func TestConcurrentSimulate(t *testing.T) {
+	ch := NewConsistentHash(20)
+	ch.SetNodes([]Node{{Name: "Node1"}, {Name: "Node2"}})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			ch.AddNode("Node" + strconv.Itoa(i+3))
+		}
+	}()
+	// 模拟时可以同时变更节点
+	keys := []string{"key1", "key2", "key3"}
+	for i := 0; i < 100; i++ {
+		total := 0
+		for _, cnt := range ch.Simulate(keys) {
+			total += cnt
+		}
+		if total != len(keys) {
+			t.Fatalf("Expected %d keys, got %d", len(keys), total)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,80 @@
+package consistencyhash
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"hash/crc32"
+	"math/bits"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/ecloudclub/zkit/option"
+)
+
+// HashFunc 计算虚拟节点和 key 在哈希环上的位置
+type HashFunc func(data []byte) uint64
+
+// WithHashFunc 设置哈希函数，默认为 MD5
+func WithHashFunc(fn HashFunc) option.Option[ConsistentHash] {
+	return func(c *ConsistentHash) {
+		c.hashFunc = fn
+	}
+}
+
+// MD5 取 MD5 摘要的前 4 个字节，分布均匀但速度较慢
+func MD5(data []byte) uint64 {
+	sum := md5.Sum(data)
+	return uint64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+// CRC32 使用 IEEE 多项式的 CRC32
+func CRC32(data []byte) uint64 {
+	return uint64(crc32.ChecksumIEEE(data))
+}
+
+// XXHash 使用 64 位的 xxHash，速度快且分布均匀，适合大多数场景
+func XXHash(data []byte) uint64 {
+	return xxhash.Sum64(data)
+}
+
+// Murmur3 使用种子为 0 的 32 位 MurmurHash3
+func Murmur3(data []byte) uint64 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+	var h uint32
+	n := len(data)
+	for ; len(data) >= 4; data = data[4:] {
+		k := binary.LittleEndian.Uint32(data)
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	switch len(data) {
+	case 3:
+		k ^= uint32(data[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(data[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(data[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(n)
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return uint64(h)
+}
@@ -0,0 +1,94 @@
+package consistencyhash
+
+import (
+	"math"
+	"sort"
+)
+
+// NodeStats 节点在哈希环上的分布情况
+type NodeStats struct {
+	Node         string
+	Weight       int
+	VirtualNodes int
+	// Share 节点负责的哈希空间占整个环的比例
+	Share float64
+}
+
+// Stats 哈希环的分布情况，用于在上线前调整虚拟节点倍数
+type Stats struct {
+	// Nodes 按节点名称排序
+	Nodes []NodeStats
+	// StdDev 各节点的实际份额与按权重分配的期望份额之比的标准差，越接近 0 分布越均匀
+	StdDev float64
+}
+
+// Stats 返回各节点在哈希环上的分布情况。
+// 所有虚拟节点的哈希值都小于 2^32 时按 32 位的哈希空间计算，例如 MD5 和 CRC32，否则按 64 位计算
+func (c *ConsistentHash) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	r := c.ring.Load()
+	stats := Stats{Nodes: make([]NodeStats, 0, len(c.nodes))}
+	if len(r.keys) == 0 {
+		return stats
+	}
+
+	space := math.Exp2(64)
+	if r.keys[len(r.keys)-1] <= math.MaxUint32 {
+		space = math.Exp2(32)
+	}
+	shares := make(map[string]float64, len(c.nodes))
+	vnodes := make(map[string]int, len(c.nodes))
+	for i, key := range r.keys {
+		// 每个虚拟节点负责它与前一个虚拟节点之间的哈希空间
+		var arc float64
+		if i == 0 {
+			arc = space - float64(r.keys[len(r.keys)-1]) + float64(key)
+		} else {
+			arc = float64(key - r.keys[i-1])
+		}
+		node := r.hashMap[key]
+		shares[node] += arc / space
+		vnodes[node]++
+	}
+
+	totalWeight := 0
+	for _, weight := range c.nodes {
+		totalWeight += weight
+	}
+	var sum, sumSq float64
+	for node, weight := range c.nodes {
+		ns := NodeStats{
+			Node:         node,
+			Weight:       weight,
+			VirtualNodes: vnodes[node],
+			Share:        shares[node],
+		}
+		stats.Nodes = append(stats.Nodes, ns)
+		ratio := ns.Share / (float64(weight) / float64(totalWeight))
+		sum += ratio
+		sumSq += ratio * ratio
+	}
+	n := float64(len(c.nodes))
+	mean := sum / n
+	stats.StdDev = math.Sqrt(max(sumSq/n-mean*mean, 0))
+	sort.Slice(stats.Nodes, func(i, j int) bool {
+		return stats.Nodes[i].Node < stats.Nodes[j].Node
+	})
+	return stats
+}
+
+// Simulate 返回 keys 分配到各节点的数量，用于模拟实际的 key 分布
+func (c *ConsistentHash) Simulate(keys []string) map[string]int {
+	c.mu.RLock()
+	n := len(c.nodes)
+	c.mu.RUnlock()
+	cnt := make(map[string]int, n)
+	for _, key := range keys {
+		if node := c.GetNode(key); node != "" {
+			cnt[node]++
+		}
+	}
+	return cnt
+}
@@ -0,0 +1,149 @@
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ecloudclub/zkit/loadbalance"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+)
+
+// 注册到 gRPC 的负载均衡策略名称，可以在 service config 中使用，例如
+// grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"zkit_p2c": {}}]}`)
+const (
+	P2C                = "zkit_p2c"
+	WeightedRoundRobin = "zkit_weighted_round_robin"
+	ConsistentHash     = "zkit_consistent_hash"
+)
+
+// HashKey 一致性哈希使用的 metadata key，其值相同的请求总是发往相同的节点
+const HashKey = "x-zkit-hash-key"
+
+func init() {
+	balancer.Register(NewBuilder(P2C, func() loadbalance.Balancer {
+		return loadbalance.NewP2C(nil)
+	}, nil))
+	balancer.Register(NewBuilder(WeightedRoundRobin, func() loadbalance.Balancer {
+		return loadbalance.NewWeightedRoundRobin(nil)
+	}, nil))
+	balancer.Register(NewBuilder(ConsistentHash, func() loadbalance.Balancer {
+		return loadbalance.NewConsistentHash(nil)
+	}, MetadataKey(HashKey)))
+}
+
+// KeyFunc 从请求中取出传给 Balancer.Pick 的 key
+type KeyFunc func(info balancer.PickInfo) string
+
+// MetadataKey 使用 outgoing metadata 中 key 的第一个值
+func MetadataKey(key string) KeyFunc {
+	return func(info balancer.PickInfo) string {
+		md, _ := metadata.FromOutgoingContext(info.Ctx)
+		if vals := md.Get(key); len(vals) > 0 {
+			return vals[0]
+		}
+		return ""
+	}
+}
+
+// WithHashKey 设置一致性哈希的 key
+func WithHashKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, HashKey, key)
+}
+
+type weightKey struct{}
+
+// SetWeight 设置地址的权重，一般由 resolver 调用
+func SetWeight(addr resolver.Address, weight int) resolver.Address {
+	addr.BalancerAttributes = addr.BalancerAttributes.WithValue(weightKey{}, weight)
+	return addr
+}
+
+func getWeight(addr resolver.Address) int {
+	weight, _ := addr.BalancerAttributes.Value(weightKey{}).(int)
+	return weight
+}
+
+// NewBuilder 将 zkit 的负载均衡策略适配为 gRPC 的 balancer.Builder，
+// 每个 ClientConn 使用 build 创建的独立的 Balancer，keyFunc 为 nil 时 key 为空
+func NewBuilder(name string, build loadbalance.Builder, keyFunc KeyFunc) balancer.Builder {
+	return &builder{name: name, build: build, keyFunc: keyFunc}
+}
+
+type builder struct {
+	name    string
+	build   loadbalance.Builder
+	keyFunc KeyFunc
+}
+
+func (b *builder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	pb := &pickerBuilder{balancer: b.build(), keyFunc: b.keyFunc}
+	return base.NewBalancerBuilder(b.name, pb, base.Config{HealthCheck: true}).Build(cc, opts)
+}
+
+func (b *builder) Name() string {
+	return b.name
+}
+
+// pickerBuilder 在就绪的连接变化时更新 Balancer 的节点，节点的统计数据得以保留
+type pickerBuilder struct {
+	mu       sync.Mutex
+	balancer loadbalance.Balancer
+	keyFunc  KeyFunc
+}
+
+func (pb *pickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	conns := make(map[string]balancer.SubConn, len(info.ReadySCs))
+	nodes := make([]loadbalance.Node, 0, len(info.ReadySCs))
+	for sc, sci := range info.ReadySCs {
+		addr := sci.Address.Addr
+		if _, ok := conns[addr]; ok {
+			continue
+		}
+		conns[addr] = sc
+		nodes = append(nodes, loadbalance.Node{Addr: addr, Weight: getWeight(sci.Address)})
+	}
+	pb.balancer.Update(nodes)
+	return &picker{balancer: pb.balancer, conns: conns, keyFunc: pb.keyFunc}
+}
+
+type picker struct {
+	balancer loadbalance.Balancer
+	conns    map[string]balancer.SubConn
+	keyFunc  KeyFunc
+}
+
+func (p *picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	var key string
+	if p.keyFunc != nil {
+		key = p.keyFunc(info)
+	}
+	node, done, err := p.balancer.Pick(info.Ctx, key)
+	if errors.Is(err, loadbalance.ErrNoAvailableNode) {
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	if err != nil {
+		return balancer.PickResult{}, err
+	}
+	sc, ok := p.conns[node.Addr]
+	if !ok {
+		// Balancer 已经被更新的 picker 更新过，等待新的 picker
+		done(nil)
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	return balancer.PickResult{
+		SubConn: sc,
+		Done: func(di balancer.DoneInfo) {
+			done(di.Err)
+		},
+	}, nil
+}
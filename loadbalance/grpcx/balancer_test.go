@@ -0,0 +1,115 @@
+package grpcx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// startServer 启动一个 gRPC 健康检查服务，返回地址和处理的请求数
+func startServer(t *testing.T) (string, *atomic.Int32) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	var cnt atomic.Int32
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		cnt.Add(1)
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String(), &cnt
+}
+
+func TestBalancer(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy string
+		ctx    func(i int) context.Context
+		// check 检查两个节点处理的请求数
+		check func(t *testing.T, cnt1, cnt2 int32)
+	}{
+		{
+			name:   "p2c",
+			policy: P2C,
+			ctx:    func(i int) context.Context { return context.Background() },
+			check: func(t *testing.T, cnt1, cnt2 int32) {
+				assert.Equal(t, int32(100), cnt1+cnt2)
+			},
+		},
+		{
+			name:   "weighted round robin",
+			policy: WeightedRoundRobin,
+			ctx:    func(i int) context.Context { return context.Background() },
+			check: func(t *testing.T, cnt1, cnt2 int32) {
+				// 权重为 1 和 3
+				assert.Equal(t, int32(25), cnt1)
+				assert.Equal(t, int32(75), cnt2)
+			},
+		},
+		{
+			name:   "consistent hash",
+			policy: ConsistentHash,
+			ctx: func(i int) context.Context {
+				return WithHashKey(context.Background(), "user-1")
+			},
+			check: func(t *testing.T, cnt1, cnt2 int32) {
+				// 相同的 key 总是发往相同的节点
+				assert.True(t, cnt1 == 100 || cnt2 == 100, "cnt1: %d, cnt2: %d", cnt1, cnt2)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr1, cnt1 := startServer(t)
+			addr2, cnt2 := startServer(t)
+			r := manual.NewBuilderWithScheme("zkit")
+			r.InitialState(resolver.State{Addresses: []resolver.Address{
+				SetWeight(resolver.Address{Addr: addr1}, 1),
+				SetWeight(resolver.Address{Addr: addr2}, 3),
+			}})
+			cc, err := grpc.NewClient("zkit:///test",
+				grpc.WithResolvers(r),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingConfig": [{%q: {}}]}`, tc.policy)),
+			)
+			require.NoError(t, err)
+			defer cc.Close()
+
+			client := healthpb.NewHealthClient(cc)
+			// 等待两个连接都就绪，避免只有一个节点时的请求影响结果
+			var attempt int
+			require.Eventually(t, func() bool {
+				// 一致性哈希需要不同的 key 才能发往不同的节点
+				attempt++
+				ctx := WithHashKey(context.Background(), strconv.Itoa(attempt))
+				_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+				return err == nil && cnt1.Load() > 0 && cnt2.Load() > 0
+			}, time.Second*5, time.Millisecond*10)
+			cnt1.Store(0)
+			cnt2.Store(0)
+
+			for i := 0; i < 100; i++ {
+				_, err := client.Check(tc.ctx(i), &healthpb.HealthCheckRequest{})
+				require.NoError(t, err)
+			}
+			tc.check(t, cnt1.Load(), cnt2.Load())
+		})
+	}
+}
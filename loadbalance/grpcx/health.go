@@ -0,0 +1,34 @@
+package grpcx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecloudclub/zkit/loadbalance"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthProbe 使用 gRPC 健康检查协议探测节点的 service，service 为空时探测整个服务器，
+// 可以配合 loadbalance.HealthChecker 使用
+func HealthProbe(service string, opts ...grpc.DialOption) loadbalance.Probe {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	return func(ctx context.Context, node loadbalance.Node) error {
+		cc, err := grpc.NewClient(node.Addr, opts...)
+		if err != nil {
+			return err
+		}
+		defer cc.Close()
+		resp, err := healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("zkit: 健康检查失败, 状态 %s", resp.GetStatus())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package grpcx
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ecloudclub/zkit/loadbalance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthProbe(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(server, hs)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	node := loadbalance.Node{Addr: lis.Addr().String()}
+	assert.NoError(t, HealthProbe("")(context.Background(), node))
+
+	hs.SetServingStatus("user", healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Error(t, HealthProbe("user")(context.Background(), node))
+	hs.SetServingStatus("user", healthpb.HealthCheckResponse_SERVING)
+	assert.NoError(t, HealthProbe("user")(context.Background(), node))
+}
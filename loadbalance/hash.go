@@ -0,0 +1,134 @@
+package loadbalance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ecloudclub/zkit/loadbalance/consistencyhash"
+	"github.com/ecloudclub/zkit/loadbalance/maglev"
+)
+
+// defaultReplicas 一致性哈希中权重为 1 的节点的虚拟节点数
+const defaultReplicas = 100
+
+// ConsistentHash 基于一致性哈希环的负载均衡器，相同的 key 总是选择相同的节点，
+// 节点变化时只有少量 key 被重新分配，节点的权重决定虚拟节点的数量
+type ConsistentHash struct {
+	membership
+	mu      sync.RWMutex
+	ring    *consistencyhash.ConsistentHash
+	nodes   map[string]Node
+	version uint64
+}
+
+func NewConsistentHash(nodes []Node) *ConsistentHash {
+	c := &ConsistentHash{
+		ring:  consistencyhash.NewConsistentHash(defaultReplicas, consistencyhash.WithHashFunc(consistencyhash.XXHash)),
+		nodes: make(map[string]Node),
+	}
+	c.Update(nodes)
+	return c
+}
+
+func (c *ConsistentHash) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	addr := c.ring.GetNode(key)
+	if addr == "" {
+		return Node{}, nil, ErrNoAvailableNode
+	}
+	return c.nodes[addr], noopDone, nil
+}
+
+// Update 只增删变化的节点，其余 key 的分配保持不变
+func (c *ConsistentHash) Update(nodes []Node) {
+	latest := make(map[string]Node, len(nodes))
+	for _, node := range nodes {
+		latest[node.Addr] = node
+	}
+
+	c.mu.Lock()
+	added, removed, changed := diff(c.nodes, latest)
+	if !changed {
+		c.mu.Unlock()
+		return
+	}
+	ringNodes := make([]consistencyhash.Node, 0, len(latest))
+	for addr, node := range latest {
+		ringNodes = append(ringNodes, consistencyhash.Node{Name: addr, Weight: node.Weight})
+	}
+	c.ring.SetNodes(ringNodes)
+	c.nodes = latest
+	version, listeners := c.changed()
+	c.version = version
+	c.mu.Unlock()
+
+	notify(listeners, added, removed)
+}
+
+// Snapshot 返回当前的节点集合及其版本
+func (c *ConsistentHash) Snapshot() Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return snapshotNodes(c.version, c.nodes)
+}
+
+// Maglev 基于 Maglev 查找表的负载均衡器，相同的 key 总是选择相同的节点，
+// 每次选择的时间复杂度为 O(1)，但不支持权重
+type Maglev struct {
+	membership
+	mu      sync.RWMutex
+	table   *maglev.Maglev
+	nodes   map[string]Node
+	version uint64
+}
+
+func NewMaglev(nodes []Node) *Maglev {
+	m := &Maglev{table: maglev.New()}
+	m.Update(nodes)
+	return m
+}
+
+func (m *Maglev) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	addr := m.table.GetNode(key)
+	if addr == "" {
+		return Node{}, nil, ErrNoAvailableNode
+	}
+	return m.nodes[addr], noopDone, nil
+}
+
+func (m *Maglev) Update(nodes []Node) {
+	latest := make(map[string]Node, len(nodes))
+	addrs := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if _, ok := latest[node.Addr]; !ok {
+			addrs = append(addrs, node.Addr)
+		}
+		latest[node.Addr] = node
+	}
+
+	m.mu.Lock()
+	added, removed, changed := diff(m.nodes, latest)
+	if !changed {
+		m.mu.Unlock()
+		return
+	}
+	m.table.SetNodes(addrs)
+	m.nodes = latest
+	version, listeners := m.changed()
+	m.version = version
+	m.mu.Unlock()
+
+	notify(listeners, added, removed)
+}
+
+// Snapshot 返回当前的节点集合及其版本
+func (m *Maglev) Snapshot() Snapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return snapshotNodes(m.version, m.nodes)
+}
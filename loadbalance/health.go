@@ -0,0 +1,245 @@
+package loadbalance
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// Probe 探测节点是否健康，返回 nil 表示健康
+type Probe func(ctx context.Context, node Node) error
+
+// TCPProbe 能建立 TCP 连接即为健康
+func TCPProbe() Probe {
+	return func(ctx context.Context, node Node) error {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", node.Addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// HTTPProbe 请求节点的 path，响应 2xx 即为健康
+func HTTPProbe(path string) Probe {
+	return func(ctx context.Context, node Node) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+node.Addr+path, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("zkit: 健康检查失败, 状态码 %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// HealthChecker 定期探测节点，将连续失败的节点从被包装的 Balancer 中摘除，
+// 连续成功后再恢复，可以包装任意 Balancer。
+// 所有节点都不健康时，Pick 返回 ErrNoAvailableNode
+type HealthChecker struct {
+	balancer Balancer
+	probe    Probe
+
+	interval           time.Duration
+	timeout            time.Duration
+	unhealthyThreshold int
+	healthyThreshold   int
+
+	mu     sync.Mutex
+	nodes  []Node
+	states map[string]*healthState
+	// updateMu 保证按顺序更新 Balancer，更新时不持有 mu，
+	// 因此 Balancer 同步调用的 OnChange 订阅者可以访问 HealthChecker
+	updateMu sync.Mutex
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+}
+
+type healthState struct {
+	healthy bool
+	// successes 和 failures 为连续成功和失败的次数
+	successes int
+	failures  int
+}
+
+// WithCheckInterval 设置探测的间隔，默认为 5 秒
+func WithCheckInterval(d time.Duration) option.Option[HealthChecker] {
+	return func(h *HealthChecker) {
+		h.interval = d
+	}
+}
+
+// WithCheckTimeout 设置每次探测的超时时间，默认为 1 秒
+func WithCheckTimeout(d time.Duration) option.Option[HealthChecker] {
+	return func(h *HealthChecker) {
+		h.timeout = d
+	}
+}
+
+// WithUnhealthyThreshold 设置连续失败多少次后摘除节点，默认为 3 次
+func WithUnhealthyThreshold(n int) option.Option[HealthChecker] {
+	return func(h *HealthChecker) {
+		h.unhealthyThreshold = n
+	}
+}
+
+// WithHealthyThreshold 设置连续成功多少次后恢复节点，默认为 2 次
+func WithHealthyThreshold(n int) option.Option[HealthChecker] {
+	return func(h *HealthChecker) {
+		h.healthyThreshold = n
+	}
+}
+
+// NewHealthChecker 包装 b 并开始探测，不再使用时需要调用 Close
+func NewHealthChecker(b Balancer, probe Probe, opts ...option.Option[HealthChecker]) *HealthChecker {
+	h := &HealthChecker{
+		balancer:           b,
+		probe:              probe,
+		interval:           5 * time.Second,
+		timeout:            time.Second,
+		unhealthyThreshold: 3,
+		healthyThreshold:   2,
+		states:             make(map[string]*healthState),
+		closeCh:            make(chan struct{}),
+	}
+	option.Apply(h, opts...)
+	go h.loop()
+	return h
+}
+
+func (h *HealthChecker) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	return h.balancer.Pick(ctx, key)
+}
+
+// Update 替换节点，新节点在探测失败前视为健康
+func (h *HealthChecker) Update(nodes []Node) {
+	h.updateMu.Lock()
+	defer h.updateMu.Unlock()
+
+	h.mu.Lock()
+	h.nodes = make([]Node, len(nodes))
+	copy(h.nodes, nodes)
+	states := make(map[string]*healthState, len(nodes))
+	for _, node := range nodes {
+		if s, ok := h.states[node.Addr]; ok {
+			states[node.Addr] = s
+			continue
+		}
+		states[node.Addr] = &healthState{healthy: true}
+	}
+	h.states = states
+	healthy := h.healthyNodes()
+	h.mu.Unlock()
+	h.balancer.Update(healthy)
+}
+
+// Track 转发给被包装的 Balancer，见 Tracker
+func (h *HealthChecker) Track(addr string) func(err error) {
+	return track(h.balancer, addr)
+}
+
+// Healthy 返回节点是否健康
+func (h *HealthChecker) Healthy(addr string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.states[addr]
+	return ok && s.healthy
+}
+
+// Close 停止探测
+func (h *HealthChecker) Close() {
+	h.closeOnce.Do(func() {
+		close(h.closeCh)
+	})
+}
+
+func (h *HealthChecker) loop() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			h.check()
+		case <-h.closeCh:
+			return
+		}
+	}
+}
+
+// check 并发探测所有节点，并在健康状态变化时更新 Balancer
+func (h *HealthChecker) check() {
+	h.mu.Lock()
+	nodes := h.nodes
+	h.mu.Unlock()
+
+	results := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+			defer cancel()
+			results[i] = h.probe(ctx, node)
+		}()
+	}
+	wg.Wait()
+
+	h.updateMu.Lock()
+	defer h.updateMu.Unlock()
+	h.mu.Lock()
+	changed := false
+	for i, node := range nodes {
+		// 探测期间节点可能已经被移除
+		s, ok := h.states[node.Addr]
+		if !ok {
+			continue
+		}
+		if results[i] != nil {
+			s.successes = 0
+			s.failures++
+			if s.healthy && s.failures >= h.unhealthyThreshold {
+				s.healthy = false
+				changed = true
+			}
+			continue
+		}
+		s.failures = 0
+		s.successes++
+		if !s.healthy && s.successes >= h.healthyThreshold {
+			s.healthy = true
+			changed = true
+		}
+	}
+	if !changed {
+		h.mu.Unlock()
+		return
+	}
+	healthy := h.healthyNodes()
+	h.mu.Unlock()
+	h.balancer.Update(healthy)
+}
+
+// healthyNodes 返回健康的节点，只有它们会交给 Balancer，调用方需要持有 mu
+func (h *HealthChecker) healthyNodes() []Node {
+	healthy := make([]Node, 0, len(h.nodes))
+	for _, node := range h.nodes {
+		if h.states[node.Addr].healthy {
+			healthy = append(healthy, node)
+		}
+	}
+	return healthy
+}
@@ -0,0 +1,141 @@
+package loadbalance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecker(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		down = map[string]bool{}
+	)
+	probe := func(ctx context.Context, node Node) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if down[node.Addr] {
+			return errors.New("mock error")
+		}
+		return nil
+	}
+	setDown := func(addr string, d bool) {
+		mu.Lock()
+		down[addr] = d
+		mu.Unlock()
+	}
+
+	h := NewHealthChecker(NewRoundRobin(nil), probe,
+		WithCheckInterval(time.Millisecond),
+		WithUnhealthyThreshold(2),
+		WithHealthyThreshold(2))
+	defer h.Close()
+	h.Update([]Node{{Addr: "a"}, {Addr: "b"}})
+	assert.True(t, h.Healthy("a"))
+
+	// b 被摘除后只会选中 a
+	setDown("b", true)
+	require.Eventually(t, func() bool {
+		return !h.Healthy("b")
+	}, time.Second, time.Millisecond)
+	for i := 0; i < 4; i++ {
+		node, _, err := h.Pick(context.Background(), "")
+		require.NoError(t, err)
+		assert.Equal(t, "a", node.Addr)
+	}
+
+	// 全部不健康
+	setDown("a", true)
+	require.Eventually(t, func() bool {
+		_, _, err := h.Pick(context.Background(), "")
+		return errors.Is(err, ErrNoAvailableNode)
+	}, time.Second, time.Millisecond)
+
+	// 恢复
+	setDown("a", false)
+	setDown("b", false)
+	require.Eventually(t, func() bool {
+		return h.Healthy("a") && h.Healthy("b")
+	}, time.Second, time.Millisecond)
+	picked := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		node, _, err := h.Pick(context.Background(), "")
+		require.NoError(t, err)
+		picked[node.Addr] = true
+	}
+	assert.Equal(t, map[string]bool{"a": true, "b": true}, picked)
+
+	// 移除的节点不再被探测
+	h.Update([]Node{{Addr: "a"}})
+	assert.False(t, h.Healthy("b"))
+}
+
+// notifyBalancer 在 Update 中同步调用 fn，模拟 OnChange 的订阅者
+type notifyBalancer struct {
+	Balancer
+	fn func(nodes []Node)
+}
+
+func (b *notifyBalancer) Update(nodes []Node) {
+	b.Balancer.Update(nodes)
+	b.fn(nodes)
+}
+
+func TestHealthChecker_ReentrantUpdate(t *testing.T) {
+	var down atomic.Bool
+	probe := func(ctx context.Context, node Node) error {
+		if down.Load() && node.Addr == "b" {
+			return errors.New("mock error")
+		}
+		return nil
+	}
+	var h *HealthChecker
+	var healthy atomic.Int32
+	b := &notifyBalancer{Balancer: NewRoundRobin(nil), fn: func(nodes []Node) {
+		// 订阅者在回调中访问 HealthChecker 不会死锁
+		cnt := int32(0)
+		for _, addr := range []string{"a", "b"} {
+			if h.Healthy(addr) {
+				cnt++
+			}
+		}
+		healthy.Store(cnt)
+	}}
+	h = NewHealthChecker(b, probe, WithCheckInterval(time.Millisecond), WithUnhealthyThreshold(1))
+	defer h.Close()
+
+	h.Update([]Node{{Addr: "a"}, {Addr: "b"}})
+	assert.Equal(t, int32(2), healthy.Load())
+	down.Store(true)
+	require.Eventually(t, func() bool {
+		return healthy.Load() == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	addr := strings.TrimPrefix(server.URL, "http://")
+	node := Node{Addr: addr}
+
+	ctx := context.Background()
+	assert.NoError(t, TCPProbe()(ctx, node))
+	assert.NoError(t, HTTPProbe("/health")(ctx, node))
+	assert.Error(t, HTTPProbe("/other")(ctx, node))
+
+	server.Close()
+	assert.Error(t, TCPProbe()(ctx, node))
+	assert.Error(t, HTTPProbe("/health")(ctx, node))
+}
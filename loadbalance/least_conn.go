@@ -0,0 +1,107 @@
+package loadbalance
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// LeastConn 最少连接，选择进行中请求数与权重之比最小的节点，适合请求耗时差异大的场景。
+// 进行中的请求数在 Pick 时增加，在调用 done 时减少
+type LeastConn struct {
+	mu    sync.Mutex // 保护 Update
+	nodes atomic.Pointer[[]*connNode]
+	// next 相同负载时轮流选择，避免总是选中第一个节点
+	next atomic.Uint64
+}
+
+type connNode struct {
+	node   Node
+	weight int64
+	// inflight 节点更新后依然沿用，使之前选择的请求结束时能正确减少
+	inflight *atomic.Int64
+}
+
+func NewLeastConn(nodes []Node) *LeastConn {
+	l := &LeastConn{}
+	l.nodes.Store(&[]*connNode{})
+	l.Update(nodes)
+	return l
+}
+
+func (l *LeastConn) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	nodes := *l.nodes.Load()
+	if len(nodes) == 0 {
+		return Node{}, nil, ErrNoAvailableNode
+	}
+
+	start := int(l.next.Add(1) % uint64(len(nodes)))
+	best := nodes[start]
+	bestInflight := best.inflight.Load()
+	for i := 1; i < len(nodes); i++ {
+		n := nodes[(start+i)%len(nodes)]
+		inflight := n.inflight.Load()
+		// 比较 inflight / weight 的大小
+		if inflight*best.weight < bestInflight*n.weight {
+			best, bestInflight = n, inflight
+		}
+	}
+
+	return best.node, best.acquire(), nil
+}
+
+// Track 统计一次发往 addr 的请求，见 Tracker
+func (l *LeastConn) Track(addr string) func(err error) {
+	for _, n := range *l.nodes.Load() {
+		if n.node.Addr == addr {
+			return n.acquire()
+		}
+	}
+	return noopDone
+}
+
+// acquire 增加进行中的请求数，返回的 done 减少它
+func (n *connNode) acquire() func(err error) {
+	n.inflight.Add(1)
+	var released atomic.Bool
+	return func(err error) {
+		// 多次调用 done 只生效一次
+		if released.CompareAndSwap(false, true) {
+			n.inflight.Add(-1)
+		}
+	}
+}
+
+// Inflight 返回节点进行中的请求数
+func (l *LeastConn) Inflight(addr string) int64 {
+	for _, n := range *l.nodes.Load() {
+		if n.node.Addr == addr {
+			return n.inflight.Load()
+		}
+	}
+	return 0
+}
+
+// Update 替换节点，已存在的节点保留进行中的请求数
+func (l *LeastConn) Update(nodes []Node) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	old := make(map[string]*connNode)
+	for _, n := range *l.nodes.Load() {
+		old[n.node.Addr] = n
+	}
+	cns := make([]*connNode, 0, len(nodes))
+	for _, node := range nodes {
+		cn := &connNode{
+			node:     node,
+			weight:   int64(max(node.Weight, 1)),
+			inflight: &atomic.Int64{},
+		}
+		if o, ok := old[node.Addr]; ok {
+			cn.inflight = o.inflight
+		}
+		cns = append(cns, cn)
+	}
+	l.nodes.Store(&cns)
+}
@@ -0,0 +1,47 @@
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeastConn(t *testing.T) {
+	l := NewLeastConn(nil)
+	_, _, err := l.Pick(context.Background(), "")
+	assert.Equal(t, ErrNoAvailableNode, err)
+
+	l.Update([]Node{{Addr: "a"}, {Addr: "b", Weight: 2}})
+	picked := make(map[string]int)
+	dones := make(map[string][]func(error))
+	for i := 0; i < 6; i++ {
+		node, done, err := l.Pick(context.Background(), "")
+		require.NoError(t, err)
+		picked[node.Addr]++
+		dones[node.Addr] = append(dones[node.Addr], done)
+	}
+	// b 的权重为 2，进行中的请求数也是 a 的 2 倍
+	assert.Equal(t, map[string]int{"a": 2, "b": 4}, picked)
+
+	// b 的请求结束后，新的请求都选择 b
+	for _, done := range dones["b"] {
+		done(nil)
+		// 重复调用 done 不会重复减少
+		done(nil)
+	}
+	assert.Equal(t, int64(0), l.Inflight("b"))
+	node, _, err := l.Pick(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "b", node.Addr)
+
+	// 更新节点后保留进行中的请求数
+	l.Update([]Node{{Addr: "a"}, {Addr: "c"}})
+	assert.Equal(t, int64(2), l.Inflight("a"))
+	dones["a"][0](nil)
+	assert.Equal(t, int64(1), l.Inflight("a"))
+	node, _, err = l.Pick(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "c", node.Addr)
+}
@@ -0,0 +1,179 @@
+package maglev
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/ecloudclub/zkit/option"
+)
+
+// DefaultTableSize 默认的查找表大小，是一个素数
+const DefaultTableSize = 65537
+
+// Maglev 基于查找表的一致性哈希，查找的时间复杂度为 O(1)，
+// 节点变化时只有少量 key 被重新分配
+type Maglev struct {
+	tableSize uint64
+	mu        sync.Mutex // 保护节点变更
+	// perms 缓存每个节点的排列参数，节点变化时无需重新计算其余节点的哈希
+	perms map[string]permutation
+	// table 查找表，重建后整体替换，查找时无需加锁
+	table atomic.Pointer[lookupTable]
+}
+
+type permutation struct {
+	offset uint64
+	skip   uint64
+}
+
+type lookupTable struct {
+	nodes   []string
+	entries []int32 // 表项到 nodes 下标的映射
+}
+
+// WithTableSize 设置查找表的大小，不是素数时使用不小于它的最小素数。
+// 查找表应远大于节点数，一般为节点数的 100 倍以上，以保证分布均匀
+func WithTableSize(size uint64) option.Option[Maglev] {
+	return func(m *Maglev) {
+		m.tableSize = nextPrime(size)
+	}
+}
+
+// New 创建一个 Maglev 实例
+func New(opts ...option.Option[Maglev]) *Maglev {
+	m := &Maglev{
+		tableSize: DefaultTableSize,
+		perms:     make(map[string]permutation),
+	}
+	option.Apply(m, opts...)
+	m.table.Store(&lookupTable{})
+	return m
+}
+
+// AddNode 添加节点并重建查找表
+func (m *Maglev) AddNode(node string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.perms[node]; ok {
+		return // 节点已存在
+	}
+	m.perms[node] = m.permutation(node)
+	m.rebuild()
+}
+
+// RemoveNode 移除节点并重建查找表
+func (m *Maglev) RemoveNode(node string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.perms[node]; !ok {
+		return // 节点不存在
+	}
+	delete(m.perms, node)
+	m.rebuild()
+}
+
+// SetNodes 将节点整体替换为 nodes，只重建一次查找表，适合批量变更
+func (m *Maglev) SetNodes(nodes []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	perms := make(map[string]permutation, len(nodes))
+	for _, node := range nodes {
+		if p, ok := m.perms[node]; ok {
+			perms[node] = p
+			continue
+		}
+		perms[node] = m.permutation(node)
+	}
+	m.perms = perms
+	m.rebuild()
+}
+
+// Nodes 返回所有节点
+func (m *Maglev) Nodes() []string {
+	t := m.table.Load()
+	nodes := make([]string, len(t.nodes))
+	copy(nodes, t.nodes)
+	return nodes
+}
+
+// GetNode 获取key对应的节点，没有节点时返回空字符串
+func (m *Maglev) GetNode(key string) string {
+	t := m.table.Load()
+	if len(t.nodes) == 0 {
+		return ""
+	}
+	idx := t.entries[xxhash.Sum64String(key)%uint64(len(t.entries))]
+	return t.nodes[idx]
+}
+
+// permutation 计算节点在查找表中的偏移量和步长
+func (m *Maglev) permutation(node string) permutation {
+	return permutation{
+		offset: xxhash.Sum64String(node) % m.tableSize,
+		skip:   xxhash.Sum64String(node+"#skip")%(m.tableSize-1) + 1,
+	}
+}
+
+// rebuild 按照 Maglev 论文的算法填充查找表，各节点轮流按自己的排列抢占空闲的表项
+func (m *Maglev) rebuild() {
+	nodes := make([]string, 0, len(m.perms))
+	for node := range m.perms {
+		nodes = append(nodes, node)
+	}
+	// 排序保证相同的节点集合得到相同的查找表
+	sort.Strings(nodes)
+	if len(nodes) == 0 {
+		m.table.Store(&lookupTable{})
+		return
+	}
+
+	entries := make([]int32, m.tableSize)
+	for i := range entries {
+		entries[i] = -1
+	}
+	next := make([]uint64, len(nodes))
+	for filled := uint64(0); ; {
+		for i, node := range nodes {
+			p := m.perms[node]
+			c := (p.offset + next[i]*p.skip) % m.tableSize
+			for entries[c] >= 0 {
+				next[i]++
+				c = (p.offset + next[i]*p.skip) % m.tableSize
+			}
+			entries[c] = int32(i)
+			next[i]++
+			filled++
+			if filled == m.tableSize {
+				m.table.Store(&lookupTable{nodes: nodes, entries: entries})
+				return
+			}
+		}
+	}
+}
+
+func nextPrime(n uint64) uint64 {
+	if n <= 2 {
+		return 2
+	}
+	if n%2 == 0 {
+		n++
+	}
+	for !isPrime(n) {
+		n += 2
+	}
+	return n
+}
+
+func isPrime(n uint64) bool {
+	for i := uint64(3); i*i <= n; i += 2 {
+		if n%i == 0 {
+			return false
+		}
+	}
+	return n%2 != 0 || n == 2
+}
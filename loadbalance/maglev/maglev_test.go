@@ -0,0 +1,70 @@
+package maglev
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaglev(t *testing.T) {
+	m := New(WithTableSize(1000))
+	assert.Equal(t, uint64(1009), m.tableSize)
+	assert.Equal(t, "", m.GetNode("key"))
+
+	nodes := []string{"Node1", "Node2", "Node3", "Node4"}
+	for _, node := range nodes {
+		m.AddNode(node)
+	}
+	assert.ElementsMatch(t, nodes, m.Nodes())
+
+	// 每个节点分到的表项数量大致相同
+	cnt := make(map[int32]int)
+	for _, e := range m.table.Load().entries {
+		cnt[e]++
+	}
+	for _, c := range cnt {
+		assert.InDelta(t, 1009/4, c, 2)
+	}
+
+	mapping := make(map[string]string)
+	for i := 0; i < 1000; i++ {
+		key := "key" + strconv.Itoa(i)
+		mapping[key] = m.GetNode(key)
+	}
+
+	// 移除节点后，只有属于该节点的 key 被重新分配，其余绝大部分保持不变
+	m.RemoveNode("Node2")
+	moved := 0
+	for key, node := range mapping {
+		got := m.GetNode(key)
+		assert.NotEqual(t, "Node2", got)
+		if node != "Node2" && got != node {
+			moved++
+		}
+	}
+	assert.Less(t, moved, 100)
+
+	// 批量设置节点与逐个添加得到相同的查找表
+	other := New(WithTableSize(1000))
+	other.SetNodes([]string{"Node4", "Node3", "Node1"})
+	assert.Equal(t, m.table.Load(), other.table.Load())
+
+	m.SetNodes(nil)
+	assert.Equal(t, "", m.GetNode("key"))
+}
+
+func TestNextPrime(t *testing.T) {
+	testCases := map[uint64]uint64{
+		0:     2,
+		2:     2,
+		3:     3,
+		4:     5,
+		9:     11,
+		65536: 65537,
+		65537: 65537,
+	}
+	for n, want := range testCases {
+		assert.Equal(t, want, nextPrime(n), n)
+	}
+}
@@ -0,0 +1,98 @@
+package loadbalance
+
+import (
+	"sort"
+	"sync"
+)
+
+// Snapshot 某一时刻的节点集合，Version 在每次节点变化时增加
+type Snapshot struct {
+	Version uint64
+	// Nodes 按地址排序
+	Nodes []Node
+}
+
+// membership 记录节点集合的版本并通知节点变化，由哈希环类的 Balancer 嵌入
+type membership struct {
+	mu        sync.Mutex
+	version   uint64
+	nextID    int
+	listeners map[int]func(added, removed []Node)
+}
+
+// OnChange 订阅节点变化，例如预热新节点的连接或清理缓存，返回的函数用于取消订阅。
+// fn 在 Update 中同步调用，调用时哈希环已经更新完成；权重的变化只增加版本，不会通知
+func (m *membership) OnChange(fn func(added, removed []Node)) (cancel func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.listeners == nil {
+		m.listeners = make(map[int]func(added, removed []Node))
+	}
+	id := m.nextID
+	m.nextID++
+	m.listeners[id] = fn
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.listeners, id)
+	}
+}
+
+// changed 增加版本，返回版本号和需要通知的订阅者
+func (m *membership) changed() (uint64, []func(added, removed []Node)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.version++
+	fns := make([]func(added, removed []Node), 0, len(m.listeners))
+	for _, fn := range m.listeners {
+		fns = append(fns, fn)
+	}
+	return m.version, fns
+}
+
+// notify 在 Balancer 释放锁之后调用，订阅者可以在回调中访问 Balancer
+func notify(listeners []func(added, removed []Node), added, removed []Node) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	for _, fn := range listeners {
+		fn(added, removed)
+	}
+}
+
+// diff 比较新旧节点，返回新增、移除的节点以及是否有任何变化
+func diff(old, latest map[string]Node) (added, removed []Node, changed bool) {
+	for addr, node := range latest {
+		o, ok := old[addr]
+		if !ok {
+			added = append(added, node)
+		}
+		changed = changed || o != node
+	}
+	for addr, node := range old {
+		if _, ok := latest[addr]; !ok {
+			removed = append(removed, node)
+			changed = true
+		}
+	}
+	sortNodes(added)
+	sortNodes(removed)
+	return added, removed, changed
+}
+
+func sortNodes(nodes []Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Addr < nodes[j].Addr
+	})
+}
+
+func snapshotNodes(version uint64, nodes map[string]Node) Snapshot {
+	s := Snapshot{Version: version, Nodes: make([]Node, 0, len(nodes))}
+	for _, node := range nodes {
+		s.Nodes = append(s.Nodes, node)
+	}
+	sortNodes(s.Nodes)
+	return s
+}
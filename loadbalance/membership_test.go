@@ -0,0 +1,46 @@
+package loadbalance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembership(t *testing.T) {
+	for _, b := range []interface {
+		Balancer
+		OnChange(fn func(added, removed []Node)) func()
+		Snapshot() Snapshot
+	}{NewConsistentHash(nil), NewMaglev(nil)} {
+		var added, removed []Node
+		cancel := b.OnChange(func(a, r []Node) {
+			added, removed = a, r
+			// 回调中可以访问 Balancer
+			_ = b.Snapshot()
+		})
+
+		b.Update([]Node{{Addr: "b"}, {Addr: "a"}})
+		assert.Equal(t, []Node{{Addr: "a"}, {Addr: "b"}}, added)
+		assert.Empty(t, removed)
+		assert.Equal(t, Snapshot{Version: 1, Nodes: []Node{{Addr: "a"}, {Addr: "b"}}}, b.Snapshot())
+
+		// 没有变化时版本不变
+		b.Update([]Node{{Addr: "a"}, {Addr: "b"}})
+		assert.Equal(t, uint64(1), b.Snapshot().Version)
+
+		b.Update([]Node{{Addr: "a"}, {Addr: "c"}})
+		assert.Equal(t, []Node{{Addr: "c"}}, added)
+		assert.Equal(t, []Node{{Addr: "b"}}, removed)
+
+		// 权重变化只增加版本
+		added, removed = nil, nil
+		b.Update([]Node{{Addr: "a", Weight: 2}, {Addr: "c"}})
+		assert.Nil(t, added)
+		assert.Equal(t, uint64(3), b.Snapshot().Version)
+
+		cancel()
+		b.Update(nil)
+		assert.Nil(t, removed)
+		assert.Equal(t, Snapshot{Version: 4, Nodes: []Node{}}, b.Snapshot())
+	}
+}
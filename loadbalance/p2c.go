@@ -0,0 +1,173 @@
+package loadbalance
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+const (
+	// defaultDecay 延迟的 EWMA 的衰减时间
+	defaultDecay = 10 * time.Second
+	// forcePick 节点超过这段时间没有被选中时强制选择一次，以更新它的延迟
+	forcePick = time.Second
+	// errorPenalty 失败的请求按这个延迟统计
+	errorPenalty = time.Second
+)
+
+// P2C 随机选择两个节点，再从中选择负载较低的一个。
+// 负载为延迟的 EWMA 与进行中的请求数的乘积再除以权重，
+// 兼顾了随机选择的低开销和最少负载的效果，适合 RPC 客户端
+type P2C struct {
+	mu    sync.Mutex // 保护 Update
+	nodes atomic.Pointer[[]*p2cNode]
+	decay time.Duration
+}
+
+type p2cNode struct {
+	addr     string
+	inflight atomic.Int64
+	// lastPick 上次被选中的时间，单位为纳秒
+	lastPick atomic.Int64
+
+	mu sync.Mutex
+	// node 和 weight 在权重变化时更新，统计数据得以保留
+	node   Node
+	weight float64
+	// ewma 延迟的指数加权移动平均，单位为纳秒
+	ewma float64
+	// stamp 上次更新 ewma 的时间
+	stamp time.Time
+}
+
+// WithDecay 设置延迟的 EWMA 的衰减时间，越小越侧重最近的请求，默认为 10 秒
+func WithDecay(d time.Duration) option.Option[P2C] {
+	return func(p *P2C) {
+		p.decay = d
+	}
+}
+
+func NewP2C(nodes []Node, opts ...option.Option[P2C]) *P2C {
+	p := &P2C{decay: defaultDecay}
+	option.Apply(p, opts...)
+	p.nodes.Store(&[]*p2cNode{})
+	p.Update(nodes)
+	return p
+}
+
+func (p *P2C) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	nodes := *p.nodes.Load()
+	var picked *p2cNode
+	switch len(nodes) {
+	case 0:
+		return Node{}, nil, ErrNoAvailableNode
+	case 1:
+		picked = nodes[0]
+	default:
+		i := rand.IntN(len(nodes))
+		j := rand.IntN(len(nodes) - 1)
+		if j >= i {
+			j++
+		}
+		picked = p.choose(nodes[i], nodes[j])
+	}
+
+	picked.mu.Lock()
+	node := picked.node
+	picked.mu.Unlock()
+	return node, p.acquire(picked), nil
+}
+
+// Track 统计一次发往 addr 的请求，见 Tracker
+func (p *P2C) Track(addr string) func(err error) {
+	for _, n := range *p.nodes.Load() {
+		if n.addr == addr {
+			return p.acquire(n)
+		}
+	}
+	return noopDone
+}
+
+// acquire 增加进行中的请求数，返回的 done 减少它并记录延迟
+func (p *P2C) acquire(n *p2cNode) func(err error) {
+	start := time.Now()
+	n.inflight.Add(1)
+	n.lastPick.Store(start.UnixNano())
+	var finished atomic.Bool
+	return func(err error) {
+		if !finished.CompareAndSwap(false, true) {
+			return
+		}
+		n.inflight.Add(-1)
+		latency := time.Since(start)
+		if err != nil {
+			latency = max(latency, errorPenalty)
+		}
+		n.observe(latency, p.decay)
+	}
+}
+
+// choose 选择负载较低的节点，但另一个节点太久没有被选中时选择它
+func (p *P2C) choose(a, b *p2cNode) *p2cNode {
+	if a.load() > b.load() {
+		a, b = b, a
+	}
+	if time.Since(time.Unix(0, b.lastPick.Load())) > forcePick {
+		return b
+	}
+	return a
+}
+
+func (n *p2cNode) load() float64 {
+	n.mu.Lock()
+	ewma, weight := n.ewma, n.weight
+	n.mu.Unlock()
+	// 加 1 避免新节点和空闲节点的负载都为 0
+	return (ewma + 1) * float64(n.inflight.Load()+1) / weight
+}
+
+// observe 更新延迟的 EWMA，距离上次更新越久，旧值的权重越低
+func (n *p2cNode) observe(latency time.Duration, decay time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := time.Now()
+	if n.stamp.IsZero() {
+		n.ewma = float64(latency)
+	} else {
+		w := math.Exp(-float64(now.Sub(n.stamp)) / float64(decay))
+		n.ewma = n.ewma*w + float64(latency)*(1-w)
+	}
+	n.stamp = now
+}
+
+// Update 替换节点，已存在的节点保留统计数据
+func (p *P2C) Update(nodes []Node) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	old := make(map[string]*p2cNode)
+	for _, n := range *p.nodes.Load() {
+		old[n.addr] = n
+	}
+	pns := make([]*p2cNode, 0, len(nodes))
+	for _, node := range nodes {
+		if o, ok := old[node.Addr]; ok {
+			o.mu.Lock()
+			o.node = node
+			o.weight = float64(max(node.Weight, 1))
+			o.mu.Unlock()
+			pns = append(pns, o)
+			continue
+		}
+		pn := &p2cNode{addr: node.Addr, node: node, weight: float64(max(node.Weight, 1))}
+		pn.lastPick.Store(time.Now().UnixNano())
+		pns = append(pns, pn)
+	}
+	p.nodes.Store(&pns)
+}
@@ -0,0 +1,69 @@
+package loadbalance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestP2C(t *testing.T) {
+	p := NewP2C(nil)
+	_, _, err := p.Pick(context.Background(), "")
+	assert.Equal(t, ErrNoAvailableNode, err)
+
+	p.Update([]Node{{Addr: "a"}})
+	node, done, err := p.Pick(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", node.Addr)
+	done(nil)
+
+	p.Update([]Node{{Addr: "a"}, {Addr: "b"}, {Addr: "c"}})
+	// a 的请求一直失败，负载远高于其余节点
+	for i := 0; i < 100; i++ {
+		node, done, err := p.Pick(context.Background(), "")
+		require.NoError(t, err)
+		if node.Addr == "a" {
+			done(errors.New("mock error"))
+		} else {
+			done(nil)
+		}
+	}
+
+	cnt := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		node, done, err := p.Pick(context.Background(), "")
+		require.NoError(t, err)
+		cnt[node.Addr]++
+		done(nil)
+	}
+	assert.Less(t, cnt["a"], 10)
+	assert.Greater(t, cnt["b"], 80)
+	assert.Greater(t, cnt["c"], 80)
+}
+
+func TestP2C_Choose(t *testing.T) {
+	p := NewP2C(nil, WithDecay(time.Second))
+	a := &p2cNode{weight: 1}
+	b := &p2cNode{weight: 1}
+	now := time.Now().UnixNano()
+	a.lastPick.Store(now)
+	b.lastPick.Store(now)
+
+	a.observe(time.Millisecond, p.decay)
+	b.observe(100*time.Millisecond, p.decay)
+	assert.Same(t, a, p.choose(a, b))
+	assert.Same(t, a, p.choose(b, a))
+
+	// 进行中的请求多也会使负载升高
+	a.inflight.Store(200)
+	assert.Same(t, b, p.choose(a, b))
+
+	// b 太久没有被选中，强制选择一次
+	a.inflight.Store(0)
+	b.lastPick.Store(time.Now().Add(-2 * forcePick).UnixNano())
+	assert.Same(t, b, p.choose(a, b))
+}
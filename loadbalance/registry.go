@@ -0,0 +1,51 @@
+package loadbalance
+
+import (
+	"fmt"
+	"sync"
+)
+
+// 内置的策略名称
+const (
+	RoundRobinName         = "round_robin"
+	WeightedRoundRobinName = "weighted_round_robin"
+	LeastConnName          = "least_conn"
+	P2CName                = "p2c"
+	WeightedRandomName     = "weighted_random"
+	ConsistentHashName     = "consistent_hash"
+	MaglevName             = "maglev"
+)
+
+// Builder 创建负载均衡器，之后通过 Update 设置节点
+type Builder func() Balancer
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Builder{
+		RoundRobinName:         func() Balancer { return NewRoundRobin(nil) },
+		WeightedRoundRobinName: func() Balancer { return NewWeightedRoundRobin(nil) },
+		LeastConnName:          func() Balancer { return NewLeastConn(nil) },
+		P2CName:                func() Balancer { return NewP2C(nil) },
+		WeightedRandomName:     func() Balancer { return NewWeightedRandom(nil) },
+		ConsistentHashName:     func() Balancer { return NewConsistentHash(nil) },
+		MaglevName:             func() Balancer { return NewMaglev(nil) },
+	}
+)
+
+// Register 注册策略，同名的策略会被覆盖，一般在 init 中调用
+func Register(name string, b Builder) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = b
+}
+
+// Get 根据策略名称创建负载均衡器，以便通过配置选择策略
+func Get(name string) (Balancer, error) {
+	registryMu.RLock()
+	b, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("zkit: 未知的负载均衡策略 %s", name)
+	}
+	return b(), nil
+}
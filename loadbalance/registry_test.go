@@ -0,0 +1,67 @@
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	names := []string{
+		RoundRobinName,
+		WeightedRoundRobinName,
+		LeastConnName,
+		P2CName,
+		WeightedRandomName,
+		ConsistentHashName,
+		MaglevName,
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			b, err := Get(name)
+			require.NoError(t, err)
+			_, _, err = b.Pick(context.Background(), "key")
+			assert.Equal(t, ErrNoAvailableNode, err)
+
+			b.Update([]Node{{Addr: "a", Weight: 1}})
+			node, done, err := b.Pick(context.Background(), "key")
+			require.NoError(t, err)
+			assert.Equal(t, Node{Addr: "a", Weight: 1}, node)
+			done(nil)
+		})
+	}
+
+	_, err := Get("not_exist")
+	assert.Error(t, err)
+
+	Register("first", func() Balancer { return NewRoundRobin(nil) })
+	b, err := Get("first")
+	require.NoError(t, err)
+	assert.IsType(t, &RoundRobin{}, b)
+}
+
+func TestHashBalancer(t *testing.T) {
+	for _, b := range []Balancer{NewConsistentHash(nil), NewMaglev(nil)} {
+		b.Update([]Node{{Addr: "a"}, {Addr: "b"}, {Addr: "c"}})
+		mapping := make(map[string]string)
+		for _, key := range []string{"k1", "k2", "k3", "k4", "k5", "k6"} {
+			node, _, err := b.Pick(context.Background(), key)
+			require.NoError(t, err)
+			mapping[key] = node.Addr
+		}
+
+		// 相同的 key 总是选择相同的节点，移除节点只影响属于它的 key
+		b.Update([]Node{{Addr: "a"}, {Addr: "c"}})
+		for key, addr := range mapping {
+			node, _, err := b.Pick(context.Background(), key)
+			require.NoError(t, err)
+			if addr != "b" {
+				assert.Equal(t, addr, node.Addr)
+			} else {
+				assert.NotEqual(t, "b", node.Addr)
+			}
+		}
+	}
+}
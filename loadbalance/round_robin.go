@@ -0,0 +1,36 @@
+package loadbalance
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// RoundRobin 轮询，依次选择每个节点，适合无状态且性能相近的节点
+type RoundRobin struct {
+	nodes atomic.Pointer[[]Node]
+	next  atomic.Uint64
+}
+
+func NewRoundRobin(nodes []Node) *RoundRobin {
+	r := &RoundRobin{}
+	r.Update(nodes)
+	return r
+}
+
+func (r *RoundRobin) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	// 零值的 RoundRobin 没有节点
+	p := r.nodes.Load()
+	if p == nil || len(*p) == 0 {
+		return Node{}, nil, ErrNoAvailableNode
+	}
+	nodes := *p
+	idx := (r.next.Add(1) - 1) % uint64(len(nodes))
+	return nodes[idx], noopDone, nil
+}
+
+func (r *RoundRobin) Update(nodes []Node) {
+	// 复制一份，避免调用方修改
+	cp := make([]Node, len(nodes))
+	copy(cp, nodes)
+	r.nodes.Store(&cp)
+}
@@ -0,0 +1,48 @@
+package loadbalance
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundRobin(t *testing.T) {
+	r := NewRoundRobin(nil)
+	_, _, err := r.Pick(context.Background(), "")
+	assert.Equal(t, ErrNoAvailableNode, err)
+
+	// 零值同样可用
+	var zero RoundRobin
+	_, _, err = zero.Pick(context.Background(), "")
+	assert.Equal(t, ErrNoAvailableNode, err)
+
+	nodes := []Node{{Addr: "a"}, {Addr: "b"}, {Addr: "c"}}
+	r.Update(nodes)
+	for i := 0; i < 6; i++ {
+		node, done, err := r.Pick(context.Background(), "")
+		require.NoError(t, err)
+		assert.Equal(t, nodes[i%3], node)
+		done(nil)
+	}
+
+	// 并发选择时依然均匀
+	cnt := make(map[string]int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			node, _, err := r.Pick(context.Background(), "")
+			assert.NoError(t, err)
+			mu.Lock()
+			cnt[node.Addr]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, map[string]int{"a": 10, "b": 10, "c": 10}, cnt)
+}
@@ -0,0 +1,156 @@
+package loadbalance
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// weightScale 放大权重，使预热中的节点能按比例得到较小的整数权重
+const weightScale = 100
+
+// SlowStart 慢启动，新加入的节点的权重在预热时间内从一小部分线性增加到配置的权重，
+// 避免缓存为空或刚启动的实例立即承受全部流量。
+// 适用于加权的策略，例如 WeightedRoundRobin、WeightedRandom、LeastConn 和 P2C，不适用于哈希类的策略。
+// 第一次 Update 的节点视为已经预热
+type SlowStart struct {
+	balancer Balancer
+	window   time.Duration
+	minRatio float64
+
+	mu          sync.Mutex
+	nodes       []Node
+	originals   map[string]Node
+	addedAt     map[string]time.Time
+	initialized bool
+	lastRefresh time.Time
+	// applied 是上次交给被包装的 Balancer 的节点，权重没有变化时不再重复 Update
+	applied []Node
+	// warming 是否有节点正在预热，没有时 Pick 无需加锁
+	warming atomic.Bool
+}
+
+// WithSlowStartWindow 设置预热时间，默认为 30 秒
+func WithSlowStartWindow(d time.Duration) option.Option[SlowStart] {
+	return func(s *SlowStart) {
+		s.window = d
+	}
+}
+
+// WithMinWeightRatio 设置新节点的初始权重占配置权重的比例，默认为 0.1
+func WithMinWeightRatio(ratio float64) option.Option[SlowStart] {
+	return func(s *SlowStart) {
+		s.minRatio = ratio
+	}
+}
+
+func NewSlowStart(b Balancer, opts ...option.Option[SlowStart]) *SlowStart {
+	s := &SlowStart{
+		balancer:  b,
+		window:    30 * time.Second,
+		minRatio:  0.1,
+		originals: make(map[string]Node),
+		addedAt:   make(map[string]time.Time),
+	}
+	option.Apply(s, opts...)
+	return s
+}
+
+func (s *SlowStart) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	if s.warming.Load() {
+		s.mu.Lock()
+		// 每过预热时间的 1/20 更新一次权重
+		if now := time.Now(); now.Sub(s.lastRefresh) >= s.window/20 {
+			s.refresh(now)
+		}
+		s.mu.Unlock()
+	}
+
+	node, done, err := s.balancer.Pick(ctx, key)
+	if err != nil {
+		return node, done, err
+	}
+	s.mu.Lock()
+	if original, ok := s.originals[node.Addr]; ok {
+		node = original
+	}
+	s.mu.Unlock()
+	return node, done, nil
+}
+
+func (s *SlowStart) Update(nodes []Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	originals := make(map[string]Node, len(nodes))
+	addedAt := make(map[string]time.Time, len(nodes))
+	for _, node := range nodes {
+		originals[node.Addr] = node
+		_, existed := s.originals[node.Addr]
+		if t, ok := s.addedAt[node.Addr]; ok {
+			addedAt[node.Addr] = t
+		} else if !existed && s.initialized {
+			addedAt[node.Addr] = now
+		}
+	}
+	s.nodes = make([]Node, len(nodes))
+	copy(s.nodes, nodes)
+	s.originals = originals
+	s.addedAt = addedAt
+	s.initialized = true
+	s.refresh(now)
+}
+
+// Track 转发给被包装的 Balancer，见 Tracker
+func (s *SlowStart) Track(addr string) func(err error) {
+	return track(s.balancer, addr)
+}
+
+// Ratio 返回节点当前的权重占配置权重的比例
+func (s *SlowStart) Ratio(addr string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ratio(addr, time.Now())
+}
+
+// ratio 调用方需要持有锁
+func (s *SlowStart) ratio(addr string, now time.Time) float64 {
+	t, ok := s.addedAt[addr]
+	if !ok {
+		return 1
+	}
+	elapsed := now.Sub(t)
+	if elapsed >= s.window {
+		return 1
+	}
+	return s.minRatio + (1-s.minRatio)*float64(elapsed)/float64(s.window)
+}
+
+// refresh 按预热进度重新计算权重并更新被包装的 Balancer，调用方需要持有锁
+func (s *SlowStart) refresh(now time.Time) {
+	warming := false
+	scaled := make([]Node, len(s.nodes))
+	for i, node := range s.nodes {
+		ratio := s.ratio(node.Addr, now)
+		if ratio < 1 {
+			warming = true
+		} else {
+			// 预热完成，之后不再需要记录
+			delete(s.addedAt, node.Addr)
+		}
+		node.Weight = max(int(float64(max(node.Weight, 1)*weightScale)*ratio), 1)
+		scaled[i] = node
+	}
+	s.lastRefresh = now
+	s.warming.Store(warming)
+	if slices.Equal(scaled, s.applied) {
+		return
+	}
+	s.applied = scaled
+	s.balancer.Update(scaled)
+}
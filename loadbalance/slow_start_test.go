@@ -0,0 +1,48 @@
+package loadbalance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlowStart(t *testing.T) {
+	s := NewSlowStart(NewWeightedRoundRobin(nil),
+		WithSlowStartWindow(200*time.Millisecond),
+		WithMinWeightRatio(0.1))
+	// 第一次 Update 的节点不需要预热
+	s.Update([]Node{{Addr: "a", Weight: 1}})
+	assert.Equal(t, 1.0, s.Ratio("a"))
+	assert.False(t, s.warming.Load())
+
+	s.Update([]Node{{Addr: "a", Weight: 1}, {Addr: "b", Weight: 1}})
+	assert.True(t, s.warming.Load())
+	assert.InDelta(t, 0.1, s.Ratio("b"), 0.05)
+
+	count := func() map[string]int {
+		cnt := make(map[string]int)
+		for i := 0; i < 110; i++ {
+			node, done, err := s.Pick(context.Background(), "")
+			require.NoError(t, err)
+			// 返回的是配置的节点
+			assert.Equal(t, 1, node.Weight)
+			cnt[node.Addr]++
+			done(nil)
+		}
+		return cnt
+	}
+	cnt := count()
+	assert.Less(t, cnt["b"], cnt["a"]/3)
+
+	// 预热完成后流量均匀
+	require.Eventually(t, func() bool {
+		_, _, _ = s.Pick(context.Background(), "")
+		return !s.warming.Load()
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1.0, s.Ratio("b"))
+	cnt = count()
+	assert.InDelta(t, cnt["a"], cnt["b"], 2)
+}
@@ -0,0 +1,124 @@
+package loadbalance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// Sticky 会话保持，相同会话的请求在有效期内总是选择同一个节点，适合有状态的后端。
+// Pick 的 key 为会话 ID，为空时直接使用被包装的 Balancer 选择。
+// 会话的节点被移除或不健康时，重新选择节点。
+// 被包装的 Balancer 实现了 Tracker 时，会话命中的请求同样计入节点的负载，因此可以包装 LeastConn、P2C 等策略
+type Sticky struct {
+	balancer Balancer
+	ttl      time.Duration
+	healthy  func(addr string) bool
+
+	mu        sync.Mutex
+	sessions  map[string]*session
+	nodes     map[string]bool
+	lastSweep time.Time
+}
+
+type session struct {
+	node     Node
+	expireAt time.Time
+}
+
+// WithSessionTTL 设置会话的有效期，每次使用后重新计算，默认为 30 分钟
+func WithSessionTTL(ttl time.Duration) option.Option[Sticky] {
+	return func(s *Sticky) {
+		s.ttl = ttl
+	}
+}
+
+// WithHealthy 设置判断节点是否健康的函数。
+// 被包装的 Balancer 是 *HealthChecker 时默认使用它的 Healthy
+func WithHealthy(fn func(addr string) bool) option.Option[Sticky] {
+	return func(s *Sticky) {
+		s.healthy = fn
+	}
+}
+
+func NewSticky(b Balancer, opts ...option.Option[Sticky]) *Sticky {
+	s := &Sticky{
+		balancer: b,
+		ttl:      30 * time.Minute,
+		sessions: make(map[string]*session),
+		nodes:    make(map[string]bool),
+	}
+	if h, ok := b.(interface{ Healthy(addr string) bool }); ok {
+		s.healthy = h.Healthy
+	}
+	option.Apply(s, opts...)
+	return s
+}
+
+func (s *Sticky) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	if key == "" {
+		return s.balancer.Pick(ctx, key)
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.sweep(now)
+	if ss, ok := s.sessions[key]; ok && now.Before(ss.expireAt) && s.available(ss.node.Addr) {
+		ss.expireAt = now.Add(s.ttl)
+		node := ss.node
+		s.mu.Unlock()
+		return node, track(s.balancer, node.Addr), nil
+	}
+	s.mu.Unlock()
+
+	node, done, err := s.balancer.Pick(ctx, key)
+	if err != nil {
+		return Node{}, nil, err
+	}
+	s.mu.Lock()
+	s.sessions[key] = &session{node: node, expireAt: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return node, done, nil
+}
+
+// Track 转发给被包装的 Balancer，见 Tracker
+func (s *Sticky) Track(addr string) func(err error) {
+	return track(s.balancer, addr)
+}
+
+// Forget 删除会话，下次请求时重新选择节点
+func (s *Sticky) Forget(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+func (s *Sticky) Update(nodes []Node) {
+	s.mu.Lock()
+	s.nodes = make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		s.nodes[node.Addr] = true
+	}
+	s.mu.Unlock()
+	s.balancer.Update(nodes)
+}
+
+// available 判断会话的节点是否还能使用，调用方需要持有锁
+func (s *Sticky) available(addr string) bool {
+	return s.nodes[addr] && (s.healthy == nil || s.healthy(addr))
+}
+
+// sweep 每过一个有效期清理一次过期的会话，调用方需要持有锁
+func (s *Sticky) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.ttl {
+		return
+	}
+	s.lastSweep = now
+	for id, ss := range s.sessions {
+		if !now.Before(ss.expireAt) {
+			delete(s.sessions, id)
+		}
+	}
+}
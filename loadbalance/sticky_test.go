@@ -0,0 +1,93 @@
+package loadbalance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSticky(t *testing.T) {
+	unhealthy := map[string]bool{}
+	s := NewSticky(NewRoundRobin(nil),
+		WithSessionTTL(50*time.Millisecond),
+		WithHealthy(func(addr string) bool {
+			return !unhealthy[addr]
+		}))
+	_, _, err := s.Pick(context.Background(), "user1")
+	assert.Equal(t, ErrNoAvailableNode, err)
+
+	s.Update([]Node{{Addr: "a"}, {Addr: "b"}})
+	pick := func(key string) string {
+		node, done, err := s.Pick(context.Background(), key)
+		require.NoError(t, err)
+		done(nil)
+		return node.Addr
+	}
+
+	// 相同的会话总是选择同一个节点
+	first := pick("user1")
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, first, pick("user1"))
+	}
+	// 没有会话时直接轮询
+	assert.NotEqual(t, pick(""), pick(""))
+
+	// 节点不健康时重新选择
+	unhealthy[first] = true
+	second := pick("user1")
+	assert.NotEqual(t, first, second)
+	unhealthy[first] = false
+	assert.Equal(t, second, pick("user1"))
+
+	// 节点被移除时重新选择
+	s.Update([]Node{{Addr: first}})
+	assert.Equal(t, first, pick("user1"))
+
+	// 过期后会话被清理
+	time.Sleep(60 * time.Millisecond)
+	pick("user2")
+	s.mu.Lock()
+	assert.NotContains(t, s.sessions, "user1")
+	s.mu.Unlock()
+
+	s.Forget("user2")
+	s.mu.Lock()
+	assert.Empty(t, s.sessions)
+	s.mu.Unlock()
+}
+
+func TestSticky_HealthChecker(t *testing.T) {
+	h := NewHealthChecker(NewRoundRobin(nil), TCPProbe(), WithCheckInterval(time.Hour))
+	defer h.Close()
+	s := NewSticky(h)
+	assert.NotNil(t, s.healthy)
+}
+
+func TestSticky_Track(t *testing.T) {
+	l := NewLeastConn([]Node{{Addr: "a"}, {Addr: "b"}})
+	s := NewSticky(NewSlowStart(l))
+	s.Update([]Node{{Addr: "a"}, {Addr: "b"}})
+
+	// 会话命中的请求也计入被包装的 LeastConn 的进行中请求数
+	var dones []func(err error)
+	var addr string
+	for i := 0; i < 3; i++ {
+		node, done, err := s.Pick(context.Background(), "user1")
+		require.NoError(t, err)
+		addr = node.Addr
+		dones = append(dones, done)
+	}
+	assert.Equal(t, int64(3), l.Inflight(addr))
+	for _, done := range dones {
+		done(nil)
+	}
+	assert.Equal(t, int64(0), l.Inflight(addr))
+
+	// 不统计负载的 Balancer 返回的 done 什么也不做
+	_, done, err := NewSticky(NewRoundRobin([]Node{{Addr: "a"}})).Pick(context.Background(), "user1")
+	require.NoError(t, err)
+	done(nil)
+}
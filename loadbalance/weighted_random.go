@@ -0,0 +1,82 @@
+package loadbalance
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// WeightedRandom 加权随机，节点被选中的概率与权重成正比。
+// 使用 alias 方法，每次选择的时间复杂度为 O(1)
+type WeightedRandom struct {
+	table atomic.Pointer[aliasTable]
+}
+
+// aliasTable 第 i 列以 prob[i] 的概率选择 nodes[i]，否则选择 nodes[alias[i]]
+type aliasTable struct {
+	nodes []Node
+	prob  []float64
+	alias []int
+}
+
+func NewWeightedRandom(nodes []Node) *WeightedRandom {
+	w := &WeightedRandom{}
+	w.Update(nodes)
+	return w
+}
+
+func (w *WeightedRandom) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	t := w.table.Load()
+	if len(t.nodes) == 0 {
+		return Node{}, nil, ErrNoAvailableNode
+	}
+	i := rand.IntN(len(t.nodes))
+	if rand.Float64() >= t.prob[i] {
+		i = t.alias[i]
+	}
+	return t.nodes[i], noopDone, nil
+}
+
+// Update 替换节点并使用 Vose 算法重建 alias 表
+func (w *WeightedRandom) Update(nodes []Node) {
+	n := len(nodes)
+	t := &aliasTable{
+		nodes: make([]Node, n),
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	copy(t.nodes, nodes)
+
+	total := 0
+	for _, node := range nodes {
+		total += max(node.Weight, 1)
+	}
+	// 将权重缩放到平均值为 1，小于 1 的列需要从大于 1 的列借概率补足
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, node := range nodes {
+		scaled[i] = float64(max(node.Weight, 1)*n) / float64(total)
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+		t.prob[s] = scaled[s]
+		t.alias[s] = l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// 剩下的列因为浮点误差可能不是严格的 1
+	for _, i := range append(small, large...) {
+		t.prob[i] = 1
+	}
+	w.table.Store(t)
+}
@@ -0,0 +1,37 @@
+package loadbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedRandom(t *testing.T) {
+	w := NewWeightedRandom(nil)
+	_, _, err := w.Pick(context.Background(), "")
+	assert.Equal(t, ErrNoAvailableNode, err)
+
+	w.Update([]Node{{Addr: "a", Weight: 1}, {Addr: "b", Weight: 2}, {Addr: "c", Weight: 7}})
+	// 每列的概率加上借出的概率之和与权重一致
+	tb := w.table.Load()
+	sum := make([]float64, len(tb.nodes))
+	for i := range tb.nodes {
+		sum[i] += tb.prob[i]
+		sum[tb.alias[i]] += 1 - tb.prob[i]
+	}
+	assert.InDeltaSlice(t, []float64{0.3, 0.6, 2.1}, sum, 1e-9)
+
+	const total = 100000
+	cnt := make(map[string]int)
+	for i := 0; i < total; i++ {
+		node, done, err := w.Pick(context.Background(), "")
+		require.NoError(t, err)
+		cnt[node.Addr]++
+		done(nil)
+	}
+	assert.InDelta(t, 0.1, float64(cnt["a"])/total, 0.01)
+	assert.InDelta(t, 0.2, float64(cnt["b"])/total, 0.01)
+	assert.InDelta(t, 0.7, float64(cnt["c"])/total, 0.01)
+}
@@ -0,0 +1,110 @@
+package loadbalance
+
+import (
+	"context"
+	"sync"
+)
+
+// WeightedRoundRobin 平滑加权轮询，与 nginx 的算法相同。
+// 每个节点被选中的比例与权重成正比，且权重高的节点不会被连续选中，例如权重为 5、1、1 时选择顺序为 a a b a c a a。
+// 请求失败时会降低节点的有效权重，之后每次成功恢复 1，直到等于配置的权重
+type WeightedRoundRobin struct {
+	mu    sync.Mutex
+	nodes []*weightedNode
+}
+
+type weightedNode struct {
+	node Node
+	// weight 配置的权重
+	weight int
+	// effectiveWeight 有效权重，请求失败时降低
+	effectiveWeight int
+	// currentWeight 当前权重，每次选择时增加有效权重，被选中后减去总权重
+	currentWeight int
+}
+
+func NewWeightedRoundRobin(nodes []Node) *WeightedRoundRobin {
+	w := &WeightedRoundRobin{}
+	w.Update(nodes)
+	return w
+}
+
+func (w *WeightedRoundRobin) Pick(ctx context.Context, key string) (Node, func(err error), error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.nodes) == 0 {
+		return Node{}, nil, ErrNoAvailableNode
+	}
+
+	var (
+		best  *weightedNode
+		total int
+	)
+	for _, n := range w.nodes {
+		n.currentWeight += n.effectiveWeight
+		total += n.effectiveWeight
+		if best == nil || n.currentWeight > best.currentWeight {
+			best = n
+		}
+	}
+	best.currentWeight -= total
+	return best.node, func(err error) {
+		w.feedback(best, err)
+	}, nil
+}
+
+// Track 根据发往 addr 的请求的结果调整有效权重，见 Tracker
+func (w *WeightedRoundRobin) Track(addr string) func(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, n := range w.nodes {
+		if n.node.Addr == addr {
+			return func(err error) {
+				w.feedback(n, err)
+			}
+		}
+	}
+	return noopDone
+}
+
+// feedback 根据请求的结果调整有效权重
+func (w *WeightedRoundRobin) feedback(n *weightedNode, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err != nil {
+		// 每次失败降低十分之一的权重，但至少保留 1，以便节点恢复后还能被选中
+		n.effectiveWeight = max(n.effectiveWeight-max(n.weight/10, 1), 1)
+		return
+	}
+	if n.effectiveWeight < n.weight {
+		n.effectiveWeight++
+	}
+}
+
+// Update 替换节点，已有节点的选择进度和降低的有效权重会保留，
+// 因此频繁调整权重时（例如 SlowStart 预热期间）选择顺序依然平滑
+func (w *WeightedRoundRobin) Update(nodes []Node) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	prev := make(map[string]*weightedNode, len(w.nodes))
+	for _, n := range w.nodes {
+		prev[n.node.Addr] = n
+	}
+	w.nodes = make([]*weightedNode, 0, len(nodes))
+	for _, node := range nodes {
+		weight := max(node.Weight, 1)
+		n := &weightedNode{
+			node:            node,
+			weight:          weight,
+			effectiveWeight: weight,
+		}
+		if p, ok := prev[node.Addr]; ok {
+			n.currentWeight = p.currentWeight
+			n.effectiveWeight = max(weight-(p.weight-p.effectiveWeight), 1)
+		}
+		w.nodes = append(w.nodes, n)
+	}
+}
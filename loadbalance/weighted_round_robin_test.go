@@ -0,0 +1,70 @@
+package loadbalance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedRoundRobin(t *testing.T) {
+	w := NewWeightedRoundRobin(nil)
+	_, _, err := w.Pick(context.Background(), "")
+	assert.Equal(t, ErrNoAvailableNode, err)
+
+	w.Update([]Node{{Addr: "a", Weight: 5}, {Addr: "b", Weight: 1}, {Addr: "c", Weight: 1}})
+	var seq string
+	for i := 0; i < 7; i++ {
+		node, done, err := w.Pick(context.Background(), "")
+		require.NoError(t, err)
+		seq += node.Addr
+		done(nil)
+	}
+	// 平滑，不会连续选中 a 5 次
+	assert.Equal(t, "aabacaa", seq)
+
+	// 更新节点不会让选择顺序重新开始
+	seq = ""
+	for i := 0; i < 7; i++ {
+		if i == 3 {
+			w.Update([]Node{{Addr: "a", Weight: 5}, {Addr: "b", Weight: 1}, {Addr: "c", Weight: 1}})
+		}
+		node, _, err := w.Pick(context.Background(), "")
+		require.NoError(t, err)
+		seq += node.Addr
+	}
+	assert.Equal(t, "aabacaa", seq)
+}
+
+func TestWeightedRoundRobin_Feedback(t *testing.T) {
+	w := NewWeightedRoundRobin([]Node{{Addr: "a", Weight: 10}, {Addr: "b", Weight: 10}})
+
+	// a 一直失败，有效权重降到 1
+	for i := 0; i < 100; i++ {
+		node, done, err := w.Pick(context.Background(), "")
+		require.NoError(t, err)
+		if node.Addr == "a" {
+			done(errors.New("mock error"))
+		} else {
+			done(nil)
+		}
+	}
+	assert.Equal(t, 1, w.nodes[0].effectiveWeight)
+	assert.Equal(t, 10, w.nodes[1].effectiveWeight)
+
+	cnt := make(map[string]int)
+	for i := 0; i < 110; i++ {
+		node, _, err := w.Pick(context.Background(), "")
+		require.NoError(t, err)
+		cnt[node.Addr]++
+	}
+	assert.Less(t, cnt["a"], cnt["b"]/3)
+
+	// 成功后逐步恢复
+	for i := 0; i < 20; i++ {
+		w.feedback(w.nodes[0], nil)
+	}
+	assert.Equal(t, 10, w.nodes[0].effectiveWeight)
+}
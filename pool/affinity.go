@@ -0,0 +1,86 @@
+package pool
+
+import (
+	"context"
+	"hash/fnv"
+	"sync/atomic"
+)
+
+// SubmitWithKey submits t so that tasks with the same key are always executed
+// by the same lane in the order they were submitted, which guarantees per-key ordering,
+// e.g. for per-user or per-entity event processing.
+//
+// The pool keeps max(minWorkers, 1) ordered lanes, each running its tasks one by one,
+// so keyed tasks do not take part in the dynamic scaling of workers.
+// It blocks until the lane accepts t, ctx is done or the pool is closed.
+func (p *WorkPool) SubmitWithKey(ctx context.Context, key string, t Task) error {
+	select {
+	case <-p.closeCh:
+		return ErrPoolClosed
+	default:
+	}
+
+	p.lanesOnce.Do(p.startLanes)
+	lane := p.lanes[laneIndex(key, len(p.lanes))]
+	t = p.traceTask(ctx, t)
+	select {
+	case lane <- t:
+		return nil
+	case <-ctx.Done():
+		abortTrace(t, ctx.Err())
+		return ctx.Err()
+	case <-p.closeCh:
+		abortTrace(t, ErrPoolClosed)
+		return ErrPoolClosed
+	}
+}
+
+// startLanes starts the ordered lanes used by SubmitWithKey.
+func (p *WorkPool) startLanes() {
+	n := int(atomic.LoadInt32(&p.minWorkers))
+	if n < 1 {
+		n = 1
+	}
+	p.lanes = make([]chan Task, n)
+	for i := range p.lanes {
+		lane := make(chan Task, p.queueSize)
+		p.lanes[i] = lane
+		go p.runLane(lane)
+	}
+}
+
+// runLane runs the tasks of a lane sequentially.
+func (p *WorkPool) runLane(lane chan Task) {
+	for {
+		select {
+		case t := <-lane:
+			if !p.waitResume() || !p.waitRate() {
+				discard(t, ErrPoolClosed)
+				p.drainLane(lane)
+				return
+			}
+			p.runTask(noWorker, t)
+		case <-p.closeCh:
+			p.drainLane(lane)
+			return
+		}
+	}
+}
+
+// drainLane discards the tasks left in a lane once the pool is closed.
+func (p *WorkPool) drainLane(lane chan Task) {
+	for {
+		select {
+		case t := <-lane:
+			discard(t, ErrPoolClosed)
+		default:
+			return
+		}
+	}
+}
+
+func laneIndex(key string, n int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(n))
+}
@@ -0,0 +1,69 @@
+package pool
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_SubmitWithKey(t *testing.T) {
+	p := NewWorkPool(4, 8, 16)
+	defer p.Close()
+
+	const keys, tasksPerKey = 8, 50
+	var (
+		mu  sync.Mutex
+		got = make(map[string][]int, keys)
+		wg  sync.WaitGroup
+	)
+	wg.Add(keys * tasksPerKey)
+	for i := 0; i < tasksPerKey; i++ {
+		for k := 0; k < keys; k++ {
+			key, seq := "user-"+strconv.Itoa(k), i
+			err := p.SubmitWithKey(context.Background(), key, taskFunc(func(ctx context.Context) error {
+				defer wg.Done()
+				mu.Lock()
+				got[key] = append(got[key], seq)
+				mu.Unlock()
+				return nil
+			}))
+			assert.NoError(t, err)
+		}
+	}
+	wg.Wait()
+
+	for k := 0; k < keys; k++ {
+		seqs := got["user-"+strconv.Itoa(k)]
+		assert.Len(t, seqs, tasksPerKey)
+		for i, seq := range seqs {
+			assert.Equal(t, i, seq)
+		}
+	}
+}
+
+func TestWorkPool_SubmitWithKeyError(t *testing.T) {
+	p := NewWorkPool(1, 2, 1)
+	p.Close()
+	err := p.SubmitWithKey(context.Background(), "key", taskFunc(func(ctx context.Context) error { return nil }))
+	assert.Equal(t, ErrPoolClosed, err)
+
+	// lane is full and ctx times out
+	p = NewWorkPool(1, 2, 1)
+	defer p.Close()
+	block := make(chan struct{})
+	defer close(block)
+	blocking := taskFunc(func(ctx context.Context) error {
+		<-block
+		return nil
+	})
+	assert.NoError(t, p.SubmitWithKey(context.Background(), "key", blocking))
+	assert.NoError(t, p.SubmitWithKey(context.Background(), "key", blocking))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = p.SubmitWithKey(ctx, "key", blocking)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
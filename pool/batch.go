@@ -0,0 +1,124 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// SubmitBatch puts all tasks into the task queue at once.
+// The admission is all-or-nothing: if the queue doesn't have room for all of them,
+// no task is submitted and ErrPoolOverloaded is returned.
+// With WithTaskQueue, the tasks are pushed to the backend one by one instead,
+// and the ones before a failed push stay submitted.
+// Tasks are traced as children of ctx, see WithTracerProvider.
+func (p *WorkPool) SubmitBatch(ctx context.Context, tasks []Task) error {
+	if p.queue != nil {
+		for _, t := range tasks {
+			if err := p.enqueue(ctx, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return p.submitBatchInMemory(ctx, tasks)
+}
+
+// submitBatchInMemory traces tasks and puts them into the in-memory task queue,
+// bypassing the task queue backend.
+func (p *WorkPool) submitBatchInMemory(ctx context.Context, tasks []Task) error {
+	wrapped := make([]Task, len(tasks))
+	for i, t := range tasks {
+		wrapped[i] = p.traceTask(ctx, t)
+	}
+	err := p.submitBatch(ctx, wrapped)
+	if err != nil {
+		for _, t := range wrapped {
+			abortTrace(t, err)
+		}
+	}
+	return err
+}
+
+func (p *WorkPool) submitBatch(ctx context.Context, tasks []Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.admitMu.Lock()
+	defer p.admitMu.Unlock()
+
+	select {
+	case <-p.closeCh:
+		return ErrPoolClosed
+	default:
+	}
+
+	// Take all the slots at once, so that either the whole batch is admitted or none of it
+	if !p.slots.tryAcquire(len(tasks)) {
+		return ErrPoolOverloaded
+	}
+	for _, t := range tasks {
+		p.push(t)
+	}
+	return nil
+}
+
+// SubmitBatchWait submits tasks like SubmitBatch and waits until all of them have completed.
+// It returns the errors of the tasks joined by errors.Join,
+// or ctx.Err() if ctx is done before they complete.
+// The tasks are always kept in memory and admitted all-or-nothing, see WithTaskQueue.
+func (p *WorkPool) SubmitBatchWait(ctx context.Context, tasks []Task) error {
+	b := &batch{}
+	b.wg.Add(len(tasks))
+	wrapped := make([]Task, len(tasks))
+	for i, t := range tasks {
+		wrapped[i] = &batchTask{b: b, t: t}
+	}
+	if err := p.submitBatchInMemory(ctx, wrapped); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return errors.Join(b.errs...)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// batch collects the results of the tasks submitted by SubmitBatchWait.
+type batch struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+type batchTask struct {
+	b *batch
+	t Task
+}
+
+func (bt *batchTask) Run(ctx context.Context) error {
+	return bt.t.Run(ctx)
+}
+
+func (bt *batchTask) unwrap() Task {
+	return bt.t
+}
+
+func (bt *batchTask) complete(err error) {
+	if err != nil {
+		bt.b.mu.Lock()
+		bt.b.errs = append(bt.b.errs, err)
+		bt.b.mu.Unlock()
+	}
+	bt.b.wg.Done()
+}
@@ -0,0 +1,77 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_SubmitBatch(t *testing.T) {
+	p := NewWorkPool(2, 4, 4)
+	defer p.Close()
+	p.Pause()
+
+	var cnt int32
+	newTasks := func(n int) []Task {
+		tasks := make([]Task, n)
+		for i := range tasks {
+			tasks[i] = taskFunc(func(ctx context.Context) error {
+				atomic.AddInt32(&cnt, 1)
+				return nil
+			})
+		}
+		return tasks
+	}
+
+	// more than the capacity, nothing is admitted
+	assert.Equal(t, ErrPoolOverloaded, p.SubmitBatch(context.Background(), newTasks(5)))
+	assert.Equal(t, 0, p.slots.len())
+
+	assert.NoError(t, p.SubmitBatch(context.Background(), newTasks(3)))
+	assert.Equal(t, 3, p.slots.len())
+
+	p.Resume()
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cnt) == 3
+	}, time.Second, time.Millisecond)
+
+	p.Close()
+	assert.Equal(t, ErrPoolClosed, p.SubmitBatch(context.Background(), newTasks(1)))
+}
+
+func TestWorkPool_SubmitBatchWait(t *testing.T) {
+	p := NewWorkPool(2, 4, 8)
+	defer p.Close()
+
+	var cnt int32
+	tasks := []Task{
+		taskFunc(func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&cnt, 1)
+			return nil
+		}),
+		taskFunc(func(ctx context.Context) error {
+			atomic.AddInt32(&cnt, 1)
+			return errors.New("mock error")
+		}),
+		taskFunc(func(ctx context.Context) error {
+			atomic.AddInt32(&cnt, 1)
+			return nil
+		}),
+	}
+	err := p.SubmitBatchWait(context.Background(), tasks)
+	assert.EqualError(t, err, "mock error")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&cnt))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = p.SubmitBatchWait(ctx, []Task{taskFunc(func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,62 @@
+package pool
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// ErrDeadLetterQueueFull is returned by the channel based DeadLetterQueue when the channel is full.
+var ErrDeadLetterQueueFull = errors.New("zkit: 死信队列已满")
+
+// DeadLetter is a task that has failed permanently, together with the metadata of the failure.
+type DeadLetter struct {
+	Task Task
+	// Err is the error of the last attempt, it is a *PanicError if the task panicked
+	Err      error
+	Attempts int
+	FailedAt time.Time
+}
+
+// DeadLetterQueue receives the tasks that have failed permanently,
+// so that they can be inspected and replayed later.
+// Push is called on the worker goroutine, so implementations should not block for long.
+type DeadLetterQueue interface {
+	Push(dl DeadLetter) error
+}
+
+// DeadLetterFunc is an adapter to allow the use of ordinary functions as DeadLetterQueue.
+type DeadLetterFunc func(dl DeadLetter) error
+
+func (f DeadLetterFunc) Push(dl DeadLetter) error {
+	return f(dl)
+}
+
+// ChanDeadLetterQueue sends dead letters to a channel without blocking.
+type ChanDeadLetterQueue chan DeadLetter
+
+func (q ChanDeadLetterQueue) Push(dl DeadLetter) error {
+	select {
+	case q <- dl:
+		return nil
+	default:
+		return ErrDeadLetterQueueFull
+	}
+}
+
+// WithMaxRetries retries a failed task up to n times before it is considered permanently failed.
+// By default, failed tasks are not retried. Tasks that panic are never retried.
+func WithMaxRetries(n int) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.maxRetries = n
+	}
+}
+
+// WithDeadLetterQueue sets the queue receiving permanently failed tasks,
+// including the ones that panicked.
+func WithDeadLetterQueue(dlq DeadLetterQueue) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.dlq = dlq
+	}
+}
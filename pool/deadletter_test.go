@@ -0,0 +1,95 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_DeadLetterQueue(t *testing.T) {
+	testCases := []struct {
+		name         string
+		maxRetries   int
+		task         func(cnt *int32) Task
+		wantDL       bool
+		wantAttempts int
+		wantErr      error
+	}{
+		{
+			name:       "succeed after retry",
+			maxRetries: 2,
+			task: func(cnt *int32) Task {
+				return taskFunc(func(ctx context.Context) error {
+					if atomic.AddInt32(cnt, 1) < 2 {
+						return errors.New("mock error")
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name:       "retries exhausted",
+			maxRetries: 2,
+			task: func(cnt *int32) Task {
+				return taskFunc(func(ctx context.Context) error {
+					atomic.AddInt32(cnt, 1)
+					return errors.New("mock error")
+				})
+			},
+			wantDL:       true,
+			wantAttempts: 3,
+			wantErr:      errors.New("mock error"),
+		},
+		{
+			name:       "panic is not retried",
+			maxRetries: 2,
+			task: func(cnt *int32) Task {
+				return taskFunc(func(ctx context.Context) error {
+					atomic.AddInt32(cnt, 1)
+					panic("mock panic")
+				})
+			},
+			wantDL:       true,
+			wantAttempts: 1,
+			wantErr:      errTaskRunningPanic,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dlq := make(ChanDeadLetterQueue, 1)
+			p := NewWorkPool(1, 2, 4, WithMaxRetries(tc.maxRetries), WithDeadLetterQueue(dlq))
+			defer p.Close()
+
+			var cnt int32
+			assert.NoError(t, p.Submit(context.Background(), tc.task(&cnt)))
+			assert.Eventually(t, func() bool {
+				return p.Stats().CompletedTasks == 1
+			}, time.Second, time.Millisecond)
+
+			if !tc.wantDL {
+				assert.Len(t, dlq, 0)
+				return
+			}
+			dl := <-dlq
+			assert.Equal(t, tc.wantAttempts, dl.Attempts)
+			assert.Equal(t, int32(tc.wantAttempts), atomic.LoadInt32(&cnt))
+			if errors.Is(tc.wantErr, errTaskRunningPanic) {
+				assert.ErrorIs(t, dl.Err, errTaskRunningPanic)
+			} else {
+				assert.Equal(t, tc.wantErr, dl.Err)
+			}
+			assert.False(t, dl.FailedAt.IsZero())
+		})
+	}
+}
+
+func TestChanDeadLetterQueue(t *testing.T) {
+	q := make(ChanDeadLetterQueue, 1)
+	assert.NoError(t, q.Push(DeadLetter{}))
+	assert.Equal(t, ErrDeadLetterQueueFull, q.Push(DeadLetter{}))
+}
@@ -0,0 +1,90 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is the result of a task submitted by SubmitDedup.
+type Future struct {
+	done chan struct{}
+	err  error
+}
+
+// Done returns a channel which is closed once the task has completed.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the task has completed and returns its error,
+// or returns ctx.Err() if ctx is done first.
+func (f *Future) Wait(ctx context.Context) error {
+	select {
+	case <-f.done:
+		return f.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dedup holds the futures of the tasks which are queued or running, by key.
+type dedup struct {
+	mu      sync.Mutex
+	futures map[string]*Future
+}
+
+// SubmitDedup submits t unless a task with the same key is already queued or running,
+// in which case t is discarded and the Future of that task is returned,
+// so that concurrent callers share one execution instead of recomputing the same result.
+// Once the task has completed, the next submission with the key runs again.
+// Like SubmitWithKey, the task is always kept in memory, see WithTaskQueue.
+func (p *WorkPool) SubmitDedup(ctx context.Context, key string, t Task) (*Future, error) {
+	p.dedup.mu.Lock()
+	if f, ok := p.dedup.futures[key]; ok {
+		p.dedup.mu.Unlock()
+		return f, nil
+	}
+	if p.dedup.futures == nil {
+		p.dedup.futures = make(map[string]*Future)
+	}
+	f := &Future{done: make(chan struct{})}
+	p.dedup.futures[key] = f
+	p.dedup.mu.Unlock()
+
+	if err := p.submitInMemory(ctx, &dedupTask{p: p, key: key, f: f, t: t}); err != nil {
+		p.forget(key, f)
+		return nil, err
+	}
+	return f, nil
+}
+
+// forget removes the future of key, unless it has been replaced by another one.
+func (p *WorkPool) forget(key string, f *Future) {
+	p.dedup.mu.Lock()
+	defer p.dedup.mu.Unlock()
+	if p.dedup.futures[key] == f {
+		delete(p.dedup.futures, key)
+	}
+}
+
+type dedupTask struct {
+	p   *WorkPool
+	key string
+	f   *Future
+	t   Task
+}
+
+func (dt *dedupTask) Run(ctx context.Context) error {
+	return dt.t.Run(ctx)
+}
+
+func (dt *dedupTask) unwrap() Task {
+	return dt.t
+}
+
+func (dt *dedupTask) complete(err error) {
+	// Forget the key first, so that a submission after Wait returns runs again
+	dt.p.forget(dt.key, dt.f)
+	dt.f.err = err
+	close(dt.f.done)
+}
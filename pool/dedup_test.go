@@ -0,0 +1,53 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkPool_SubmitDedup(t *testing.T) {
+	p := NewWorkPool(2, 4, 8)
+	defer p.Close()
+
+	var cnt int32
+	block := make(chan struct{})
+	mockErr := errors.New("mock error")
+	task := taskFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&cnt, 1)
+		<-block
+		return mockErr
+	})
+
+	ctx := context.Background()
+	f1, err := p.SubmitDedup(ctx, "key", task)
+	require.NoError(t, err)
+	f2, err := p.SubmitDedup(ctx, "key", task)
+	require.NoError(t, err)
+	// coalesced into the same execution
+	assert.Same(t, f1, f2)
+
+	f3, err := p.SubmitDedup(ctx, "other", task)
+	require.NoError(t, err)
+	assert.NotSame(t, f1, f3)
+
+	close(block)
+	assert.Equal(t, mockErr, f1.Wait(ctx))
+	assert.Equal(t, mockErr, f3.Wait(ctx))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&cnt))
+
+	// runs again once completed
+	f4, err := p.SubmitDedup(ctx, "key", task)
+	require.NoError(t, err)
+	assert.NotSame(t, f1, f4)
+	assert.Equal(t, mockErr, f4.Wait(ctx))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&cnt))
+
+	p.Close()
+	_, err = p.SubmitDedup(ctx, "key", task)
+	assert.Equal(t, ErrPoolClosed, err)
+}
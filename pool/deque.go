@@ -0,0 +1,105 @@
+package pool
+
+import "sync"
+
+// deque is the local task queue of a worker.
+// The owner takes tasks from the front, while other workers steal from the back.
+// Each deque has its own lock, so workers never contend on a global one.
+type deque struct {
+	mu     sync.Mutex
+	buf    []Task
+	head   int
+	size   int
+	closed bool
+}
+
+// pushBack appends t, it returns false if the deque has been closed.
+func (d *deque) pushBack(t Task) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return false
+	}
+	if d.size == len(d.buf) {
+		d.grow()
+	}
+	d.buf[(d.head+d.size)%len(d.buf)] = t
+	d.size++
+	return true
+}
+
+func (d *deque) popFront() (Task, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.size == 0 {
+		return nil, false
+	}
+	t := d.buf[d.head]
+	d.buf[d.head] = nil
+	d.head = (d.head + 1) % len(d.buf)
+	d.size--
+	return t, true
+}
+
+func (d *deque) popBack() (Task, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.size == 0 {
+		return nil, false
+	}
+	i := (d.head + d.size - 1) % len(d.buf)
+	t := d.buf[i]
+	d.buf[i] = nil
+	d.size--
+	return t, true
+}
+
+func (d *deque) len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.size
+}
+
+// close rejects further pushes and returns the remaining tasks.
+func (d *deque) close() []Task {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.closed = true
+	tasks := make([]Task, 0, d.size)
+	for d.size > 0 {
+		tasks = append(tasks, d.buf[d.head])
+		d.buf[d.head] = nil
+		d.head = (d.head + 1) % len(d.buf)
+		d.size--
+	}
+	return tasks
+}
+
+// closeIfEmpty closes the deque only if it has no tasks, and reports whether it has been closed.
+func (d *deque) closeIfEmpty() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.size > 0 {
+		return false
+	}
+	d.closed = true
+	return true
+}
+
+func (d *deque) grow() {
+	n := len(d.buf) * 2
+	if n == 0 {
+		n = 8
+	}
+	buf := make([]Task, n)
+	for i := 0; i < d.size; i++ {
+		buf[i] = d.buf[(d.head+i)%len(d.buf)]
+	}
+	d.buf = buf
+	d.head = 0
+}
@@ -0,0 +1,43 @@
+package pool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type idTask int
+
+func (t idTask) Run(ctx context.Context) error {
+	return nil
+}
+
+func TestDeque(t *testing.T) {
+	d := &deque{}
+	// more than the initial capacity
+	for i := 0; i < 20; i++ {
+		assert.True(t, d.pushBack(idTask(i)))
+	}
+	assert.Equal(t, 20, d.len())
+
+	// the owner takes from the front, thieves take from the back
+	front, ok := d.popFront()
+	assert.True(t, ok)
+	assert.Equal(t, idTask(0), front)
+	back, ok := d.popBack()
+	assert.True(t, ok)
+	assert.Equal(t, idTask(19), back)
+
+	assert.False(t, d.closeIfEmpty())
+	left := d.close()
+	assert.Len(t, left, 18)
+	assert.Equal(t, idTask(1), left[0])
+	assert.False(t, d.pushBack(idTask(20)))
+	_, ok = d.popFront()
+	assert.False(t, ok)
+
+	empty := &deque{}
+	assert.True(t, empty.closeIfEmpty())
+	assert.False(t, empty.pushBack(idTask(0)))
+}
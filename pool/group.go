@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group is a collection of tasks running in a WorkPool, with semantics similar to errgroup.
+// The concurrency of a Group is limited by the pool it belongs to.
+type Group struct {
+	pool   *WorkPool
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewGroup returns a new Group whose tasks run in the pool.
+// The context passed to the tasks is derived from ctx
+// and is cancelled when a task returns an error or when Wait returns.
+func (p *WorkPool) NewGroup(ctx context.Context) *Group {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{
+		pool:   p,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go submits t to the pool, blocking until the pool accepts it.
+// If the submission fails, the error is recorded like an error returned by t.
+// Like SubmitDedup, the task is always kept in memory, see WithTaskQueue.
+func (g *Group) Go(t Task) {
+	g.wg.Add(1)
+	err := g.pool.submitInMemory(g.ctx, &groupTask{g: g, t: t})
+	if err != nil {
+		g.done(err)
+	}
+}
+
+// Wait blocks until all tasks have completed,
+// then returns all non-nil errors joined by errors.Join.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
+
+func (g *Group) done(err error) {
+	if err != nil {
+		g.mu.Lock()
+		g.errs = append(g.errs, err)
+		g.mu.Unlock()
+		g.cancel()
+	}
+	g.wg.Done()
+}
+
+// groupTask runs the task with the context of the group and reports the result to it.
+type groupTask struct {
+	g *Group
+	t Task
+}
+
+func (gt *groupTask) Run(_ context.Context) error {
+	return gt.t.Run(gt.g.ctx)
+}
+
+func (gt *groupTask) unwrap() Task {
+	return gt.t
+}
+
+func (gt *groupTask) complete(err error) {
+	gt.g.done(err)
+}
@@ -0,0 +1,81 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tasks   []Task
+		wantErr error
+	}{
+		{
+			name: "all succeed",
+			tasks: []Task{
+				taskFunc(func(ctx context.Context) error { return nil }),
+				taskFunc(func(ctx context.Context) error { return nil }),
+			},
+		},
+		{
+			name: "errors are joined",
+			tasks: []Task{
+				taskFunc(func(ctx context.Context) error { return errors.New("mock error 1") }),
+				taskFunc(func(ctx context.Context) error { return nil }),
+			},
+			wantErr: errors.New("mock error 1"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewWorkPool(2, 4, 4)
+			defer p.Close()
+			g := p.NewGroup(context.Background())
+			for _, task := range tc.tasks {
+				g.Go(task)
+			}
+			err := g.Wait()
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr.Error())
+		})
+	}
+}
+
+func TestGroup_CancelOnError(t *testing.T) {
+	p := NewWorkPool(2, 4, 4)
+	defer p.Close()
+	g := p.NewGroup(context.Background())
+
+	var cancelled int32
+	g.Go(taskFunc(func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			atomic.StoreInt32(&cancelled, 1)
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	}))
+	g.Go(taskFunc(func(ctx context.Context) error {
+		return errors.New("mock error")
+	}))
+	g.Go(taskFunc(func(ctx context.Context) error {
+		panic("mock panic")
+	}))
+
+	err := g.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errTaskRunningPanic)
+	assert.ErrorContains(t, err, "mock error")
+}
@@ -0,0 +1,33 @@
+package pool
+
+import (
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// noWorker is the worker id passed to the task hooks
+// when a task is not run by a worker, e.g. by OverloadCallerRuns or SubmitWithKey.
+const noWorker = -1
+
+// Hooks are callbacks of the lifecycle of workers and tasks,
+// allowing applications to bind per-worker resources and emit custom telemetry.
+// All of them are optional.
+type Hooks struct {
+	// OnWorkerStart is called on the goroutine of the worker before it runs any task.
+	OnWorkerStart func(workerID int)
+	// OnWorkerStop is called on the goroutine of the worker when it exits.
+	OnWorkerStop func(workerID int)
+	// OnTaskStart is called before a task runs.
+	// workerID is -1 if the task is not run by a worker.
+	OnTaskStart func(workerID int, t Task)
+	// OnTaskDone is called after a task has completed, including its retries.
+	OnTaskDone func(workerID int, t Task, err error, elapsed time.Duration)
+}
+
+// WithHooks sets the lifecycle hooks of the pool.
+func WithHooks(hooks Hooks) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.hooks = hooks
+	}
+}
@@ -0,0 +1,69 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_Hooks(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		started = make(map[int]bool)
+		stopped = make(map[int]bool)
+		events  []string
+	)
+	done := make(chan struct{})
+	// OverloadBlock makes sure the task is run by a worker
+	p := NewWorkPool(2, 4, 4, WithOverloadPolicy(OverloadBlock), WithHooks(Hooks{
+		OnWorkerStart: func(workerID int) {
+			mu.Lock()
+			defer mu.Unlock()
+			started[workerID] = true
+		},
+		OnWorkerStop: func(workerID int) {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped[workerID] = true
+		},
+		OnTaskStart: func(workerID int, task Task) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.GreaterOrEqual(t, workerID, 0)
+			events = append(events, "start")
+		},
+		OnTaskDone: func(workerID int, task Task, err error, elapsed time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.EqualError(t, err, "mock error")
+			events = append(events, "done")
+			close(done)
+		},
+	}))
+
+	assert.NoError(t, p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+		return errors.New("mock error")
+	})))
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("OnTaskDone was not called")
+	}
+
+	p.Close()
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(stopped) == 2
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[int]bool{0: true, 1: true}, started)
+	assert.Equal(t, started, stopped)
+	assert.Equal(t, []string{"start", "done"}, events)
+}
@@ -0,0 +1,69 @@
+package pool
+
+import (
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/shirou/gopsutil/v4/process"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// ResourceUsage is the resource utilization of the process, both in the range [0, 1].
+type ResourceUsage struct {
+	// CPU is the CPU utilization normalized by the number of CPUs.
+	CPU float64
+	// Memory is the resident set size as a fraction of the total physical memory.
+	Memory float64
+}
+
+// MetricsSource samples the resource utilization used by the pool for dynamic scaling.
+// Implementations can be injected by WithMetricsSource, e.g. to simulate load in tests.
+type MetricsSource interface {
+	Sample() (ResourceUsage, error)
+}
+
+// MetricsSourceFunc is an adapter to allow the use of ordinary functions as MetricsSource.
+type MetricsSourceFunc func() (ResourceUsage, error)
+
+func (f MetricsSourceFunc) Sample() (ResourceUsage, error) {
+	return f()
+}
+
+// WithMetricsSource replaces the default source, which samples the CPU and RSS of the current process.
+func WithMetricsSource(src MetricsSource) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.metricsSource = src
+	}
+}
+
+// processMetricsSource samples the current process by gopsutil.
+type processMetricsSource struct {
+	once sync.Once
+	proc *process.Process
+	err  error
+}
+
+func (s *processMetricsSource) Sample() (ResourceUsage, error) {
+	s.once.Do(func() {
+		s.proc, s.err = process.NewProcess(int32(os.Getpid()))
+	})
+	if s.err != nil {
+		return ResourceUsage{}, s.err
+	}
+
+	// The CPU percent is calculated since the last call
+	cpu, err := s.proc.Percent(0)
+	if err != nil {
+		return ResourceUsage{}, err
+	}
+	mem, err := s.proc.MemoryPercent()
+	if err != nil {
+		return ResourceUsage{}, err
+	}
+	return ResourceUsage{
+		CPU:    cpu / 100 / float64(runtime.NumCPU()),
+		Memory: float64(mem) / 100,
+	}, nil
+}
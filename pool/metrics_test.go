@@ -0,0 +1,38 @@
+package pool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_MetricsSource(t *testing.T) {
+	usage := ResourceUsage{CPU: 0.9, Memory: 0.5}
+	var err error
+	p := NewWorkPool(1, 2, 4, WithMetricsSource(MetricsSourceFunc(func() (ResourceUsage, error) {
+		return usage, err
+	})))
+	defer p.Close()
+
+	p.updateMetrics()
+	stats := p.Stats()
+	assert.Equal(t, 0.9, stats.CPUUsage)
+	assert.Equal(t, 0.5, stats.MemoryUsage)
+
+	// keep the last values when sampling fails
+	usage, err = ResourceUsage{}, errors.New("mock error")
+	p.updateMetrics()
+	stats = p.Stats()
+	assert.Equal(t, 0.9, stats.CPUUsage)
+	assert.Equal(t, 0.5, stats.MemoryUsage)
+}
+
+func TestProcessMetricsSource(t *testing.T) {
+	src := &processMetricsSource{}
+	usage, err := src.Sample()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, usage.CPU, 0.0)
+	assert.Greater(t, usage.Memory, 0.0)
+	assert.LessOrEqual(t, usage.Memory, 1.0)
+}
@@ -0,0 +1,82 @@
+package pool
+
+import (
+	"context"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// OverloadPolicy decides what happens to a task when the pool is overloaded.
+type OverloadPolicy int
+
+const (
+	// OverloadBlock makes Submit block when the task queue is full,
+	// until there is room, ctx is done or the pool is closed. This is the default policy.
+	OverloadBlock OverloadPolicy = iota
+	// OverloadSpawn runs the task on a new goroutine when all workers are busy,
+	// ignoring the capacity of the task queue. Note that the number of goroutines is unbounded.
+	OverloadSpawn
+	// OverloadReject makes Submit return ErrPoolOverloaded when the task queue is full.
+	OverloadReject
+	// OverloadCallerRuns makes Submit run the task on the calling goroutine
+	// when the task queue is full, which slows down the producer.
+	OverloadCallerRuns
+	// OverloadDropOldest makes Submit discard a queued task to make room for the new one
+	// when the task queue is full. The discarded task is the one at the front of the first
+	// non-empty worker queue, which is an old task but not necessarily the oldest of the pool.
+	// If no queued task can be discarded, e.g. all of them are already running
+	// after the queue size has been reduced, Submit returns ErrPoolOverloaded.
+	OverloadDropOldest
+)
+
+// WithOverloadPolicy sets the policy applied when the pool is overloaded.
+// Except for OverloadSpawn, queued tasks wait for a free worker
+// instead of starting new goroutines, and the policy takes effect once the task queue is full.
+func WithOverloadPolicy(policy OverloadPolicy) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.overloadPolicy = policy
+	}
+}
+
+// handleFullQueue applies the overload policy to t when the task queue is full.
+func (p *WorkPool) handleFullQueue(ctx context.Context, t Task) error {
+	switch p.overloadPolicy {
+	case OverloadReject:
+		return ErrPoolOverloaded
+	case OverloadCallerRuns:
+		return p.runTask(noWorker, t)
+	case OverloadDropOldest:
+		p.admitMu.RLock()
+		defer p.admitMu.RUnlock()
+		for {
+			if p.slots.tryAcquire(1) {
+				p.push(t)
+				return nil
+			}
+			// Drop an old one and try again
+			if !p.dropOldest() {
+				return ErrPoolOverloaded
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrPoolOverloaded
+	}
+}
+
+// dropOldest discards the task at the front of the first non-empty local queue,
+// the dropped task completes with ErrPoolOverloaded. It reports whether a task has been dropped.
+func (p *WorkPool) dropOldest() bool {
+	for _, w := range p.loadWorkers() {
+		t, ok := w.local.popFront()
+		if !ok {
+			continue
+		}
+		p.slots.release()
+		discard(t, ErrPoolOverloaded)
+		return true
+	}
+	return false
+}
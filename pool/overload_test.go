@@ -0,0 +1,130 @@
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newBusyPool returns a pool with one worker which is blocked until the returned channel is closed,
+// and whose task queue of size 1 is full.
+func newBusyPool(t *testing.T, policy OverloadPolicy) (*WorkPool, chan struct{}) {
+	p := NewWorkPool(1, 1, 1, WithOverloadPolicy(policy))
+	block := make(chan struct{})
+	blocking := taskFunc(func(ctx context.Context) error {
+		<-block
+		return nil
+	})
+	// the first one runs on the worker, the second one fills the queue,
+	// bypass Submit so that the policy is not applied here
+	p.slots.tryAcquire(1)
+	p.push(blocking)
+	assert.Eventually(t, func() bool {
+		return p.Stats().RunningTasks == 1
+	}, time.Second, time.Millisecond)
+	p.slots.tryAcquire(1)
+	p.push(blocking)
+	return p, block
+}
+
+func TestWorkPool_OverloadPolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  OverloadPolicy
+		wantErr error
+		wantCnt int32
+	}{
+		{
+			name:    "reject",
+			policy:  OverloadReject,
+			wantErr: ErrPoolOverloaded,
+		},
+		{
+			name:    "caller runs",
+			policy:  OverloadCallerRuns,
+			wantCnt: 1,
+		},
+		{
+			name:   "drop oldest",
+			policy: OverloadDropOldest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, block := newBusyPool(t, tc.policy)
+			defer p.Close()
+			defer close(block)
+
+			var cnt int32
+			err := p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+				atomic.AddInt32(&cnt, 1)
+				return nil
+			}))
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, atomic.LoadInt32(&cnt))
+			assert.Equal(t, 1, p.slots.len())
+		})
+	}
+}
+
+func TestWorkPool_OverloadBlock(t *testing.T) {
+	p, block := newBusyPool(t, OverloadBlock)
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := p.Submit(ctx, taskFunc(func(ctx context.Context) error { return nil }))
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// all tasks are finished by the only worker once it is unblocked
+	close(block)
+	assert.Eventually(t, func() bool {
+		return p.Stats().CompletedTasks == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestWorkPool_OverloadSpawn(t *testing.T) {
+	// Submit blocks by default
+	d := New()
+	defer d.Close()
+	assert.Equal(t, OverloadBlock, d.overloadPolicy)
+
+	p, block := newBusyPool(t, OverloadSpawn)
+	defer p.Close()
+	defer close(block)
+
+	done := make(chan struct{})
+	err := p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+		close(done)
+		return nil
+	}))
+	assert.NoError(t, err)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task was not executed on a new goroutine")
+	}
+	assert.Equal(t, 1, p.slots.len())
+}
+
+func TestWorkPool_OverloadDropOldestNothingToDrop(t *testing.T) {
+	p := NewWorkPool(1, 1, 1, WithOverloadPolicy(OverloadDropOldest))
+	defer p.Close()
+	// the slot is taken but no task is queued, e.g. it is already running after SetQueueSize
+	p.slots.tryAcquire(1)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- p.Submit(context.Background(), taskFunc(func(ctx context.Context) error { return nil }))
+	}()
+	select {
+	case err := <-errCh:
+		assert.Equal(t, ErrPoolOverloaded, err)
+	case <-time.After(time.Second):
+		t.Fatal("Submit spins forever")
+	}
+}
@@ -0,0 +1,48 @@
+package pool
+
+// Pause stops the pool from dispatching tasks to workers.
+// Tasks submitted while paused are buffered in the task queue
+// until its capacity is reached, and are dispatched after Resume.
+// Tasks that are already running are not affected.
+func (p *WorkPool) Pause() {
+	p.pauseMu.Lock()
+	defer p.pauseMu.Unlock()
+	if p.resumeCh == nil {
+		p.resumeCh = make(chan struct{})
+	}
+}
+
+// Resume restarts dispatching after Pause.
+func (p *WorkPool) Resume() {
+	p.pauseMu.Lock()
+	defer p.pauseMu.Unlock()
+	if p.resumeCh != nil {
+		close(p.resumeCh)
+		p.resumeCh = nil
+	}
+}
+
+// Paused reports whether the pool is paused.
+func (p *WorkPool) Paused() bool {
+	p.pauseMu.Lock()
+	defer p.pauseMu.Unlock()
+	return p.resumeCh != nil
+}
+
+// waitResume blocks while the pool is paused.
+// It returns false if the pool is closed while waiting.
+func (p *WorkPool) waitResume() bool {
+	p.pauseMu.Lock()
+	ch := p.resumeCh
+	p.pauseMu.Unlock()
+	if ch == nil {
+		return true
+	}
+
+	select {
+	case <-ch:
+		return true
+	case <-p.closeCh:
+		return false
+	}
+}
@@ -0,0 +1,35 @@
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_PauseResume(t *testing.T) {
+	p := NewWorkPool(2, 4, 4)
+	defer p.Close()
+
+	p.Pause()
+	assert.True(t, p.Paused())
+
+	var cnt int32
+	task := taskFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&cnt, 1)
+		return nil
+	})
+	for i := 0; i < 4; i++ {
+		assert.True(t, p.TrySubmit(task))
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&cnt))
+
+	p.Resume()
+	assert.False(t, p.Paused())
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cnt) == 4
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,101 @@
+package pool
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// queueRetryInterval is how long the pool waits before popping again
+// after the task queue backend returned an error.
+const queueRetryInterval = 100 * time.Millisecond
+
+// TaskQueue is a backend holding the submitted tasks until workers are free to run them,
+// e.g. a persistent one so that queued tasks survive process restarts.
+type TaskQueue interface {
+	// Push appends t to the queue.
+	Push(ctx context.Context, t Task) error
+	// Pop blocks until a task is available or ctx is done.
+	Pop(ctx context.Context) (Delivery, error)
+}
+
+// Delivery is a task popped from a TaskQueue.
+type Delivery struct {
+	Task Task
+	// Ack is called once the task has completed, whether it succeeded or not.
+	// A task which is never acknowledged may be delivered again, which gives at-least-once processing.
+	Ack func(ctx context.Context) error
+}
+
+// WithTaskQueue makes the pool keep submitted tasks in q instead of in memory.
+// Submit, TrySubmit and SubmitBatch push tasks to q, which must be able to store them,
+// while only as many tasks as the queue size are popped from q and held in memory at a time.
+// The overload policy doesn't apply as the capacity of q is up to the backend,
+// and SubmitBatch is no longer all-or-nothing.
+// SubmitWithKey, SubmitDedup, SubmitBatchWait and Group.Go still keep their tasks in memory,
+// as their results are awaited by this process and couldn't be reported back from q.
+func WithTaskQueue(q TaskQueue) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.queue = q
+	}
+}
+
+// enqueue pushes t to the task queue backend.
+func (p *WorkPool) enqueue(ctx context.Context, t Task) error {
+	if p.closed() {
+		return ErrPoolClosed
+	}
+	return p.queue.Push(ctx, t)
+}
+
+// feed pops tasks from the task queue backend and hands them over to the workers.
+func (p *WorkPool) feed() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.closeCh
+		cancel()
+	}()
+
+	for {
+		// Don't pop more tasks than the queue size
+		if err := p.slots.acquire(ctx, p.closeCh); err != nil {
+			return
+		}
+		d, err := p.queue.Pop(ctx)
+		if err != nil {
+			p.slots.release()
+			select {
+			case <-time.After(queueRetryInterval):
+				continue
+			case <-p.closeCh:
+				return
+			}
+		}
+		p.push(p.traceTask(ctx, &deliveryTask{d: d}))
+	}
+}
+
+// deliveryTask acknowledges the delivery once the task has completed.
+type deliveryTask struct {
+	d Delivery
+}
+
+func (dt *deliveryTask) Run(ctx context.Context) error {
+	return dt.d.Task.Run(ctx)
+}
+
+func (dt *deliveryTask) unwrap() Task {
+	return dt.d.Task
+}
+
+func (dt *deliveryTask) complete(err error) {
+	if c, ok := dt.d.Task.(completer); ok {
+		c.complete(err)
+	}
+	if dt.d.Ack != nil {
+		// If it fails, the task is delivered again
+		_ = dt.d.Ack(context.Background())
+	}
+}
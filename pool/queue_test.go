@@ -0,0 +1,134 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chanTaskQueue is a TaskQueue counting the acknowledged tasks.
+type chanTaskQueue struct {
+	ch    chan Task
+	acked int32
+}
+
+func (q *chanTaskQueue) Push(ctx context.Context, t Task) error {
+	select {
+	case q.ch <- t:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *chanTaskQueue) Pop(ctx context.Context) (Delivery, error) {
+	select {
+	case t := <-q.ch:
+		return Delivery{Task: t, Ack: func(ctx context.Context) error {
+			atomic.AddInt32(&q.acked, 1)
+			return nil
+		}}, nil
+	case <-ctx.Done():
+		return Delivery{}, ctx.Err()
+	}
+}
+
+func TestWorkPool_TaskQueue(t *testing.T) {
+	q := &chanTaskQueue{ch: make(chan Task, 16)}
+	p := New(WithTaskQueue(q), WithQueueSize(2))
+
+	var cnt int32
+	task := taskFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&cnt, 1)
+		return nil
+	})
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, p.Submit(context.Background(), task))
+	}
+	assert.True(t, p.TrySubmit(task))
+	assert.NoError(t, p.SubmitBatch(context.Background(), []Task{task, task}))
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&q.acked) == 8
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(8), atomic.LoadInt32(&cnt))
+
+	p.Close()
+	assert.Equal(t, ErrPoolClosed, p.Submit(context.Background(), task))
+}
+
+// counterTask adds Delta to the counter of the codecTaskQueue it was decoded by.
+type counterTask struct {
+	Delta int32
+	cnt   *int32
+}
+
+func (ct *counterTask) Run(ctx context.Context) error {
+	atomic.AddInt32(ct.cnt, ct.Delta)
+	return nil
+}
+
+// codecTaskQueue is a TaskQueue serializing the tasks like a persistent backend does,
+// it can only encode counterTask.
+type codecTaskQueue struct {
+	ch  chan []byte
+	cnt int32
+}
+
+func (q *codecTaskQueue) Push(ctx context.Context, t Task) error {
+	ct, ok := t.(*counterTask)
+	if !ok {
+		return errors.New("mock error: unknown task")
+	}
+	data, err := json.Marshal(ct)
+	if err != nil {
+		return err
+	}
+	q.ch <- data
+	return nil
+}
+
+func (q *codecTaskQueue) Pop(ctx context.Context) (Delivery, error) {
+	select {
+	case data := <-q.ch:
+		ct := &counterTask{cnt: &q.cnt}
+		return Delivery{Task: ct}, json.Unmarshal(data, ct)
+	case <-ctx.Done():
+		return Delivery{}, ctx.Err()
+	}
+}
+
+func TestWorkPool_TaskQueueInMemory(t *testing.T) {
+	q := &codecTaskQueue{ch: make(chan []byte, 16)}
+	p := New(WithTaskQueue(q))
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var cnt int32
+	task := taskFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&cnt, 1)
+		return nil
+	})
+
+	// tasks which can't be encoded by the backend are rejected by Submit
+	assert.Error(t, p.Submit(ctx, task))
+	assert.NoError(t, p.Submit(ctx, &counterTask{Delta: 10}))
+
+	// while the results of groups and batches are awaited in memory
+	g := p.NewGroup(ctx)
+	g.Go(task)
+	g.Go(task)
+	assert.NoError(t, g.Wait())
+	assert.NoError(t, p.SubmitBatchWait(ctx, []Task{task, task}))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&cnt))
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&q.cnt) == 10
+	}, time.Second, time.Millisecond)
+}
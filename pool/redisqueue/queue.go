@@ -0,0 +1,114 @@
+package redisqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ecloudclub/zkit/option"
+	"github.com/ecloudclub/zkit/pool"
+)
+
+const defaultPopTimeout = time.Second
+
+// Codec serializes tasks so that they can be stored in Redis.
+type Codec interface {
+	Encode(t pool.Task) ([]byte, error)
+	Decode(data []byte) (pool.Task, error)
+}
+
+// Queue is a pool.TaskQueue backed by a Redis list, so that queued tasks survive process restarts.
+// A popped task is moved to a processing list and removed from it once acknowledged,
+// call Recover on startup to deliver again the tasks left there by a crashed process.
+type Queue struct {
+	client        redis.Cmdable
+	key           string
+	processingKey string
+	codec         Codec
+	popTimeout    time.Duration
+}
+
+// New returns a Queue storing the tasks encoded by codec in the list at key.
+func New(client redis.Cmdable, key string, codec Codec, opts ...option.Option[Queue]) *Queue {
+	q := &Queue{
+		client:        client,
+		key:           key,
+		processingKey: key + ":processing",
+		codec:         codec,
+		popTimeout:    defaultPopTimeout,
+	}
+	option.Apply(q, opts...)
+	return q
+}
+
+// WithProcessingKey sets the key of the processing list, which defaults to key + ":processing".
+// Each consumer process should have its own processing list if several of them share the queue.
+func WithProcessingKey(key string) option.Option[Queue] {
+	return func(q *Queue) {
+		q.processingKey = key
+	}
+}
+
+// WithPopTimeout sets how long a blocking pop waits on Redis before checking the context again.
+func WithPopTimeout(d time.Duration) option.Option[Queue] {
+	return func(q *Queue) {
+		q.popTimeout = d
+	}
+}
+
+// Push appends t to the list.
+func (q *Queue) Push(ctx context.Context, t pool.Task) error {
+	data, err := q.codec.Encode(t)
+	if err != nil {
+		return fmt.Errorf("zkit: 编码任务失败: %w", err)
+	}
+	return q.client.RPush(ctx, q.key, data).Err()
+}
+
+// Pop moves the first task of the list to the processing list and returns it.
+func (q *Queue) Pop(ctx context.Context) (pool.Delivery, error) {
+	for {
+		data, err := q.client.BLMove(ctx, q.key, q.processingKey, "LEFT", "RIGHT", q.popTimeout).Result()
+		if errors.Is(err, redis.Nil) {
+			// Timed out, wait again unless ctx is done
+			if err = ctx.Err(); err != nil {
+				return pool.Delivery{}, err
+			}
+			continue
+		}
+		if err != nil {
+			return pool.Delivery{}, err
+		}
+
+		ack := func(ctx context.Context) error {
+			return q.client.LRem(ctx, q.processingKey, 1, data).Err()
+		}
+		t, err := q.codec.Decode([]byte(data))
+		if err != nil {
+			// It would never be decoded, don't deliver it again
+			_ = ack(ctx)
+			return pool.Delivery{}, fmt.Errorf("zkit: 解码任务失败: %w", err)
+		}
+		return pool.Delivery{Task: t, Ack: ack}, nil
+	}
+}
+
+// Recover moves the tasks left in the processing list back to the head of the list,
+// and returns the number of them.
+// It must be called before the pool starts popping, usually on startup.
+func (q *Queue) Recover(ctx context.Context) (int, error) {
+	n := 0
+	for {
+		err := q.client.LMove(ctx, q.processingKey, q.key, "RIGHT", "LEFT").Err()
+		if errors.Is(err, redis.Nil) {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
@@ -0,0 +1,91 @@
+package redisqueue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ecloudclub/zkit/pool"
+)
+
+type emailTask struct {
+	To string `json:"to"`
+}
+
+func (t *emailTask) Run(ctx context.Context) error {
+	return nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(t pool.Task) ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (jsonCodec) Decode(data []byte) (pool.Task, error) {
+	t := &emailTask{}
+	err := json.Unmarshal(data, t)
+	return t, err
+}
+
+func newQueue(t *testing.T) (*Queue, *redis.Client) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return New(client, "tasks", jsonCodec{}, WithPopTimeout(10*time.Millisecond)), client
+}
+
+func TestQueue(t *testing.T) {
+	q, client := newQueue(t)
+	ctx := context.Background()
+
+	require.NoError(t, q.Push(ctx, &emailTask{To: "a"}))
+	require.NoError(t, q.Push(ctx, &emailTask{To: "b"}))
+
+	d, err := q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &emailTask{To: "a"}, d.Task)
+	assert.Equal(t, int64(1), client.LLen(ctx, "tasks:processing").Val())
+
+	require.NoError(t, d.Ack(ctx))
+	assert.Equal(t, int64(0), client.LLen(ctx, "tasks:processing").Val())
+
+	// b is left unacknowledged as if the process crashed
+	_, err = q.Pop(ctx)
+	require.NoError(t, err)
+	n, err := q.Recover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	d, err = q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &emailTask{To: "b"}, d.Task)
+
+	// blocks until ctx is done
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = q.Pop(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQueue_WorkPool(t *testing.T) {
+	q, client := newQueue(t)
+	p := pool.New(pool.WithTaskQueue(q))
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.Submit(ctx, &emailTask{To: "a"}))
+	}
+	assert.Eventually(t, func() bool {
+		return p.Stats().CompletedTasks == 3 &&
+			client.LLen(ctx, "tasks").Val() == 0 &&
+			client.LLen(ctx, "tasks:processing").Val() == 0
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,70 @@
+package pool
+
+import "sync/atomic"
+
+// SetMaxWorkers changes the maximum number of workers at runtime,
+// e.g. from an admin endpoint. Extra workers are stopped at once,
+// and the tasks queued on them are handed over to the remaining ones.
+// Like WithMaxWorkers, n is raised to the minimum number of workers if it is smaller.
+func (p *WorkPool) SetMaxWorkers(n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed() {
+		return ErrPoolClosed
+	}
+	if n < 1 {
+		n = 1
+	}
+	if n < int(p.minWorkers) {
+		n = int(p.minWorkers)
+	}
+	atomic.StoreInt32(&p.maxWorkers, int32(n))
+	p.resizeWorkers(len(p.workers))
+	return nil
+}
+
+// SetMinWorkers changes the minimum number of workers at runtime.
+// Missing workers are started at once, and the maximum number of workers
+// is raised to n if it is smaller.
+func (p *WorkPool) SetMinWorkers(n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed() {
+		return ErrPoolClosed
+	}
+	if n < 0 {
+		n = 0
+	}
+	if n > int(p.maxWorkers) {
+		atomic.StoreInt32(&p.maxWorkers, int32(n))
+	}
+	atomic.StoreInt32(&p.minWorkers, int32(n))
+	p.resizeWorkers(len(p.workers))
+	return nil
+}
+
+// SetQueueSize changes the capacity of the task queue at runtime.
+// Tasks already queued are kept when it shrinks,
+// new submissions are subject to the overload policy until the queue drains below the new capacity.
+// Like WithQueueSize, sizes below 1 are raised to 1.
+func (p *WorkPool) SetQueueSize(size int) error {
+	if p.closed() {
+		return ErrPoolClosed
+	}
+	if size < 1 {
+		size = 1
+	}
+	p.slots.resize(size)
+	return nil
+}
+
+func (p *WorkPool) closed() bool {
+	select {
+	case <-p.closeCh:
+		return true
+	default:
+		return false
+	}
+}
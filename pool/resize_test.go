@@ -0,0 +1,60 @@
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_SetWorkers(t *testing.T) {
+	p := NewWorkPool(1, 2, 4)
+
+	assert.NoError(t, p.SetMinWorkers(3))
+	stats := p.Stats()
+	assert.Equal(t, 3, stats.MinWorkers)
+	// raised to the new minimum
+	assert.Equal(t, 3, stats.MaxWorkers)
+	assert.Equal(t, 3, stats.Workers)
+
+	assert.NoError(t, p.SetMaxWorkers(8))
+	assert.Equal(t, 8, p.Stats().MaxWorkers)
+
+	assert.NoError(t, p.SetMinWorkers(1))
+	assert.NoError(t, p.SetMaxWorkers(1))
+	stats = p.Stats()
+	assert.Equal(t, 1, stats.MaxWorkers)
+	assert.Equal(t, 1, stats.Workers)
+
+	p.Close()
+	assert.Equal(t, ErrPoolClosed, p.SetMaxWorkers(2))
+	assert.Equal(t, ErrPoolClosed, p.SetMinWorkers(2))
+}
+
+func TestWorkPool_SetQueueSize(t *testing.T) {
+	p := NewWorkPool(1, 1, 1, WithOverloadPolicy(OverloadReject))
+	defer p.Close()
+	p.Pause()
+
+	task := taskFunc(func(ctx context.Context) error { return nil })
+	assert.NoError(t, p.Submit(context.Background(), task))
+	assert.Equal(t, ErrPoolOverloaded, p.Submit(context.Background(), task))
+
+	assert.NoError(t, p.SetQueueSize(3))
+	assert.Equal(t, 3, p.Stats().QueueCapacity)
+	assert.NoError(t, p.Submit(context.Background(), task))
+	assert.NoError(t, p.Submit(context.Background(), task))
+	assert.Equal(t, ErrPoolOverloaded, p.Submit(context.Background(), task))
+
+	// queued tasks are kept when it shrinks
+	assert.NoError(t, p.SetQueueSize(1))
+	assert.Equal(t, 3, p.Stats().QueuedTasks)
+	assert.Equal(t, ErrPoolOverloaded, p.Submit(context.Background(), task))
+
+	p.Resume()
+	assert.Eventually(t, func() bool {
+		return p.Stats().CompletedTasks == 3
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, p.Submit(context.Background(), task))
+}
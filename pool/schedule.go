@@ -0,0 +1,157 @@
+package pool
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ScheduledTask is the handle of a task submitted by SubmitAfter or SubmitAt.
+type ScheduledTask struct {
+	t     Task
+	at    time.Time
+	index int // index in the timer heap, -1 means fired or cancelled
+	s     *scheduler
+}
+
+// Cancel prevents the task from being submitted to the pool.
+// It returns false if the task has already been submitted or cancelled.
+func (st *ScheduledTask) Cancel() bool {
+	st.s.mu.Lock()
+	defer st.s.mu.Unlock()
+
+	if st.index < 0 {
+		return false
+	}
+	heap.Remove(&st.s.tasks, st.index)
+	return true
+}
+
+// timerHeap is a min heap of scheduled tasks ordered by their due time.
+type timerHeap []*ScheduledTask
+
+func (h timerHeap) Len() int {
+	return len(h)
+}
+
+func (h timerHeap) Less(i, j int) bool {
+	return h[i].at.Before(h[j].at)
+}
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x any) {
+	st := x.(*ScheduledTask)
+	st.index = len(*h)
+	*h = append(*h, st)
+}
+
+func (h *timerHeap) Pop() any {
+	old := *h
+	n := len(old)
+	st := old[n-1]
+	old[n-1] = nil
+	st.index = -1
+	*h = old[:n-1]
+	return st
+}
+
+// scheduler holds delayed tasks and submits them to the pool when they are due.
+type scheduler struct {
+	mu    sync.Mutex
+	tasks timerHeap
+	// wake notifies the loop that the earliest due time may have changed
+	wake chan struct{}
+}
+
+// SubmitAfter submits t to the pool after d has elapsed.
+func (p *WorkPool) SubmitAfter(d time.Duration, t Task) (*ScheduledTask, error) {
+	return p.SubmitAt(time.Now().Add(d), t)
+}
+
+// SubmitAt submits t to the pool at the given time.
+// If at is in the past, t is submitted as soon as possible.
+// A due task is submitted without blocking, so that a full queue doesn't delay the other timers:
+// if it can't be admitted, it is dropped and pushed to the dead letter queue, see WithDeadLetterQueue.
+func (p *WorkPool) SubmitAt(at time.Time, t Task) (*ScheduledTask, error) {
+	select {
+	case <-p.closeCh:
+		return nil, ErrPoolClosed
+	default:
+	}
+
+	p.schedOnce.Do(func() {
+		p.sched = &scheduler{wake: make(chan struct{}, 1)}
+		go p.runScheduler()
+	})
+
+	s := p.sched
+	st := &ScheduledTask{t: t, at: at, s: s}
+	s.mu.Lock()
+	heap.Push(&s.tasks, st)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return st, nil
+}
+
+// runScheduler waits for the earliest scheduled task to become due and submits it.
+func (p *WorkPool) runScheduler() {
+	s := p.sched
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		var due []Task
+		for len(s.tasks) > 0 && !s.tasks[0].at.After(now) {
+			due = append(due, heap.Pop(&s.tasks).(*ScheduledTask).t)
+		}
+		wait := time.Hour
+		if len(s.tasks) > 0 {
+			wait = s.tasks[0].at.Sub(now)
+		}
+		s.mu.Unlock()
+
+		for _, t := range due {
+			if err := p.submitDue(t); errors.Is(err, ErrPoolClosed) {
+				return
+			}
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-s.wake:
+		case <-p.closeCh:
+			return
+		}
+	}
+}
+
+// submitDue submits a due task without blocking the scheduler.
+// A task that can't be admitted is dropped and pushed to the dead letter queue.
+func (p *WorkPool) submitDue(t Task) error {
+	if p.TrySubmit(t) {
+		return nil
+	}
+	err := ErrPoolOverloaded
+	if p.closed() {
+		err = ErrPoolClosed
+	}
+	if p.dlq != nil {
+		// There is nowhere else to report the error of the DLQ itself
+		_ = p.dlq.Push(DeadLetter{Task: unwrapTask(t), Err: err, FailedAt: time.Now()})
+	}
+	discard(t, err)
+	return err
+}
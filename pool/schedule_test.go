@@ -0,0 +1,104 @@
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_SubmitAfter(t *testing.T) {
+	p := NewWorkPool(1, 2, 4)
+	defer p.Close()
+
+	ch := make(chan int, 3)
+	newTask := func(i int) Task {
+		return taskFunc(func(ctx context.Context) error {
+			ch <- i
+			return nil
+		})
+	}
+
+	start := time.Now()
+	_, err := p.SubmitAfter(60*time.Millisecond, newTask(2))
+	assert.NoError(t, err)
+	_, err = p.SubmitAfter(20*time.Millisecond, newTask(1))
+	assert.NoError(t, err)
+	cancelled, err := p.SubmitAfter(40*time.Millisecond, newTask(3))
+	assert.NoError(t, err)
+	assert.True(t, cancelled.Cancel())
+	assert.False(t, cancelled.Cancel())
+
+	var got []int
+	for i := 0; i < 2; i++ {
+		select {
+		case v := <-ch:
+			got = append(got, v)
+		case <-time.After(time.Second):
+			t.Fatal("scheduled task was not executed")
+		}
+	}
+	assert.Equal(t, []int{1, 2}, got)
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+
+	select {
+	case v := <-ch:
+		t.Fatalf("cancelled task %d was executed", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWorkPool_SubmitAt(t *testing.T) {
+	p := NewWorkPool(1, 2, 4)
+	done := make(chan struct{})
+	// in the past, submitted immediately
+	st, err := p.SubmitAt(time.Now().Add(-time.Second), taskFunc(func(ctx context.Context) error {
+		close(done)
+		return nil
+	}))
+	assert.NoError(t, err)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduled task was not executed")
+	}
+	assert.False(t, st.Cancel())
+
+	p.Close()
+	_, err = p.SubmitAt(time.Now(), taskFunc(func(ctx context.Context) error { return nil }))
+	assert.Equal(t, ErrPoolClosed, err)
+}
+
+func TestWorkPool_SubmitAfterFullQueue(t *testing.T) {
+	dlq := make(ChanDeadLetterQueue, 1)
+	p := NewWorkPool(1, 1, 1, WithDeadLetterQueue(dlq))
+	defer p.Close()
+	p.Pause()
+	// the queue is full, the scheduler must not be blocked by it
+	assert.True(t, p.TrySubmit(taskFunc(func(ctx context.Context) error { return nil })))
+
+	dropped := taskFunc(func(ctx context.Context) error { return nil })
+	_, err := p.SubmitAfter(time.Millisecond, dropped)
+	assert.NoError(t, err)
+	select {
+	case dl := <-dlq:
+		assert.Equal(t, ErrPoolOverloaded, dl.Err)
+	case <-time.After(time.Second):
+		t.Fatal("dropped task was not pushed to the dead letter queue")
+	}
+
+	// later timers still fire
+	p.Resume()
+	done := make(chan struct{})
+	_, err = p.SubmitAfter(time.Millisecond, taskFunc(func(ctx context.Context) error {
+		close(done)
+		return nil
+	}))
+	assert.NoError(t, err)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduled task was not executed")
+	}
+}
@@ -0,0 +1,90 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+// semaphore limits the number of queued tasks.
+// Unlike a buffered channel, its capacity can be changed at runtime.
+type semaphore struct {
+	mu       sync.Mutex
+	used     int
+	capacity int
+	// changed is closed and replaced whenever a slot may have become available
+	changed chan struct{}
+}
+
+func newSemaphore(capacity int) *semaphore {
+	return &semaphore{capacity: capacity, changed: make(chan struct{})}
+}
+
+// tryAcquire takes n slots without blocking, either all of them or none.
+func (s *semaphore) tryAcquire(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.capacity-s.used < n {
+		return false
+	}
+	s.used += n
+	return true
+}
+
+// acquire blocks until a slot is taken, ctx is done or closeCh is closed.
+func (s *semaphore) acquire(ctx context.Context, closeCh <-chan struct{}) error {
+	for {
+		s.mu.Lock()
+		if s.used < s.capacity {
+			s.used++
+			s.mu.Unlock()
+			return nil
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-closeCh:
+			return ErrPoolClosed
+		}
+	}
+}
+
+func (s *semaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.used--
+	s.broadcast()
+}
+
+// resize changes the capacity. If it shrinks below the number of used slots,
+// new acquisitions wait until enough slots have been released.
+func (s *semaphore) resize(capacity int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.capacity = capacity
+	s.broadcast()
+}
+
+func (s *semaphore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.used
+}
+
+func (s *semaphore) cap() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.capacity
+}
+
+// broadcast wakes up all the waiters, the caller must hold mu.
+func (s *semaphore) broadcast() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
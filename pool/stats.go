@@ -0,0 +1,77 @@
+package pool
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of the state of a WorkPool.
+type Stats struct {
+	MinWorkers     int
+	MaxWorkers     int
+	Workers        int
+	QueuedTasks    int
+	QueueCapacity  int
+	RunningTasks   int64
+	CompletedTasks uint64
+
+	// The following values come from PoolMetrics,
+	// which is refreshed on every adjustment interval.
+	QueueUsage     float64
+	IdleWorkers    float64
+	CPUUsage       float64
+	MemoryUsage    float64
+	AvgLatency     time.Duration
+	SuccessRate    float64
+	LastAdjustTime time.Time
+
+	// WorkerLoads holds the in-flight tasks of each worker
+	WorkerLoads []WorkerLoad
+}
+
+// WorkerLoad is the number of in-flight tasks of a worker.
+type WorkerLoad struct {
+	ID int
+	// Queued is the number of tasks waiting in the local queue of the worker
+	Queued int
+	// Running is 1 if the worker is running a task, 0 otherwise
+	Running int
+}
+
+// InFlight returns the number of tasks which are queued or running on the worker.
+func (l WorkerLoad) InFlight() int {
+	return l.Queued + l.Running
+}
+
+// Stats returns a snapshot of the current state of the pool.
+func (p *WorkPool) Stats() Stats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	loads := make([]WorkerLoad, len(p.workers))
+	for i, w := range p.workers {
+		loads[i] = WorkerLoad{
+			ID:      w.id,
+			Queued:  w.local.len(),
+			Running: int(atomic.LoadInt32(&w.running)),
+		}
+	}
+
+	return Stats{
+		MinWorkers:     int(p.minWorkers),
+		MaxWorkers:     int(p.maxWorkers),
+		Workers:        len(p.workers),
+		QueuedTasks:    p.slots.len(),
+		QueueCapacity:  p.slots.cap(),
+		RunningTasks:   atomic.LoadInt64(&p.running),
+		CompletedTasks: atomic.LoadUint64(&p.completed),
+		QueueUsage:     p.metrics.queueUsage,
+		IdleWorkers:    p.metrics.idleWorkers,
+		CPUUsage:       p.metrics.cpuUsage,
+		MemoryUsage:    p.metrics.memoryUsage,
+		AvgLatency:     time.Duration(p.metrics.avgLatency),
+		SuccessRate:    p.metrics.successRate,
+		LastAdjustTime: p.metrics.lastAdjustTime,
+		WorkerLoads:    loads,
+	}
+}
@@ -0,0 +1,61 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkPool_Stats(t *testing.T) {
+	p := NewWorkPool(2, 4, 8)
+	defer p.Close()
+
+	stats := p.Stats()
+	assert.Equal(t, 2, stats.MinWorkers)
+	assert.Equal(t, 4, stats.MaxWorkers)
+	assert.Equal(t, 2, stats.Workers)
+	assert.Equal(t, 8, stats.QueueCapacity)
+	assert.Equal(t, uint64(0), stats.CompletedTasks)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	assert.NoError(t, p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+		close(started)
+		<-block
+		return nil
+	})))
+	assert.NoError(t, p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+		return errors.New("mock error")
+	})))
+	<-started
+	stats = p.Stats()
+	assert.Equal(t, int64(1), stats.RunningTasks)
+	assert.Len(t, stats.WorkerLoads, stats.Workers)
+	inFlight := 0
+	for _, l := range stats.WorkerLoads {
+		inFlight += l.Running
+	}
+	assert.Equal(t, 1, inFlight)
+	close(block)
+
+	assert.Eventually(t, func() bool {
+		return p.Stats().CompletedTasks == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		for _, l := range p.Stats().WorkerLoads {
+			if l.InFlight() > 0 {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(0), p.Stats().RunningTasks)
+
+	p.updateMetrics()
+	stats = p.Stats()
+	assert.Equal(t, 0.5, stats.SuccessRate)
+	assert.Greater(t, stats.AvgLatency, time.Duration(0))
+}
@@ -4,16 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
+	"math/rand/v2"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+const (
+	defaultMinWorkers     = 1
+	defaultQueueSize      = 1024
+	defaultAdjustInterval = 5 * time.Second
 )
 
 var (
 	panicBuffLen        = 2048
 	errTaskRunningPanic = errors.New("zkit: Task 运行时异常")
+
+	// ErrPoolClosed is returned when submitting tasks to a closed pool.
+	ErrPoolClosed = errors.New("zkit: WorkPool 已关闭")
+	// ErrPoolOverloaded is returned by Submit when the task queue is full
+	// and the pool uses the OverloadReject policy.
+	ErrPoolOverloaded = errors.New("zkit: WorkPool 已过载")
 )
 
 // Task 代表一个任务
@@ -23,8 +40,29 @@ type Task interface {
 	Run(ctx context.Context) error
 }
 
+// PanicHandler is called with the task, the recovered value and the stack
+// of the goroutine whenever a Task panics, so that applications can report
+// the panic to their own logging or alerting system.
+type PanicHandler func(task Task, recovered any, stack []byte)
+
+// PanicError is the structured error returned when a Task panics.
+// It wraps errTaskRunningPanic, so errors.Is can still be used to detect it.
+type PanicError struct {
+	Recovered any
+	Stack     []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s：[PANIC]:\t%+v\n%s\n", errTaskRunningPanic, e.Recovered, e.Stack)
+}
+
+func (e *PanicError) Unwrap() error {
+	return errTaskRunningPanic
+}
+
 type taskWrapper struct {
-	t Task
+	t            Task
+	panicHandler PanicHandler
 }
 
 func (tw *taskWrapper) Run(ctx context.Context) (err error) {
@@ -33,42 +71,152 @@ func (tw *taskWrapper) Run(ctx context.Context) (err error) {
 		if r := recover(); r != nil {
 			buf := make([]byte, panicBuffLen)
 			buf = buf[:runtime.Stack(buf, false)]
-			err = fmt.Errorf("%w：%s", errTaskRunningPanic, fmt.Sprintf("[PANIC]:\t%+v\n%s\n", r, buf))
+			err = &PanicError{Recovered: r, Stack: buf}
+			if tw.panicHandler != nil {
+				tw.panicHandler(unwrapTask(tw.t), r, buf)
+			}
 		}
 	}()
 	return tw.t.Run(ctx)
 }
 
 type worker struct {
-	tasks chan Task
-	quit  chan struct{}
-	id    int
+	// local holds the tasks assigned to this worker, idle workers steal from it
+	local *deque
+	// signal wakes up the worker when a task is pushed to local
+	signal chan struct{}
+	quit   chan struct{}
+	id     int
+	pool   *WorkPool
+	parked int32
+	// running is set while the worker is running a task
+	running int32
 }
 
-// newWorker returns a new worker
-func newWorker(id int) *worker {
+// newWorker returns a new worker with a unique id in the pool
+func newWorker(pool *WorkPool) *worker {
 	return &worker{
-		tasks: make(chan Task),
-		quit:  make(chan struct{}),
-		id:    id,
+		local:  &deque{},
+		signal: make(chan struct{}, 1),
+		quit:   make(chan struct{}),
+		id:     int(atomic.AddInt32(&pool.nextWorkerID, 1) - 1),
+		pool:   pool,
 	}
 }
 
 // start starts a worker to begin working
 func (w *worker) start() {
 	go func() {
+		hooks := w.pool.hooks
+		if hooks.OnWorkerStart != nil {
+			hooks.OnWorkerStart(w.id)
+		}
+		if hooks.OnWorkerStop != nil {
+			defer hooks.OnWorkerStop(w.id)
+		}
+
+		// A nil channel never fires, which disables idle reaping
+		// when the pool has no idle timeout.
+		var (
+			timer *time.Timer
+			idle  <-chan time.Time
+		)
+		if w.pool.idleTimeout > 0 {
+			timer = time.NewTimer(w.pool.idleTimeout)
+			defer timer.Stop()
+			idle = timer.C
+		}
+
 		for {
 			select {
-			case t := <-w.tasks:
-				tr := &taskWrapper{t: t}
-				tr.Run(context.Background())
+			case <-w.pool.closeCh:
+				return
 			case <-w.quit:
+				w.handOver()
+				return
+			default:
+			}
+
+			if t, ok := w.next(); ok {
+				if !w.pool.waitRate() {
+					// The task has left the queue, complete it so that nobody waits for it forever
+					discard(t, ErrPoolClosed)
+					return
+				}
+				atomic.StoreInt32(&w.running, 1)
+				w.pool.runTask(w.id, t)
+				atomic.StoreInt32(&w.running, 0)
+				if timer != nil {
+					timer.Reset(w.pool.idleTimeout)
+				}
+				continue
+			}
+
+			if w.park(idle) {
 				return
 			}
+			if timer != nil {
+				timer.Reset(w.pool.idleTimeout)
+			}
 		}
 	}()
 }
 
+// next takes a task from the local queue, or steals one from other workers.
+// It blocks while the pool is paused.
+func (w *worker) next() (Task, bool) {
+	if !w.pool.waitResume() {
+		return nil, false
+	}
+	t, ok := w.local.popFront()
+	if !ok {
+		t, ok = w.pool.steal(w)
+	}
+	if ok {
+		// The task leaves the queue, release its slot
+		w.pool.slots.release()
+	}
+	return t, ok
+}
+
+// park waits until there may be new tasks. It reports whether the worker should exit.
+func (w *worker) park(idle <-chan time.Time) bool {
+	atomic.StoreInt32(&w.parked, 1)
+	atomic.AddInt32(&w.pool.idle, 1)
+	defer func() {
+		atomic.AddInt32(&w.pool.idle, -1)
+		atomic.StoreInt32(&w.parked, 0)
+	}()
+
+	select {
+	case <-w.signal:
+	case <-w.pool.notify:
+	case <-idle:
+		return w.pool.retire(w)
+	case <-w.quit:
+		w.handOver()
+		return true
+	case <-w.pool.closeCh:
+		return true
+	}
+	return false
+}
+
+// wake wakes up the worker if it is parked.
+func (w *worker) wake() {
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// handOver gives the remaining local tasks of a stopped worker to the other workers.
+func (w *worker) handOver() {
+	for _, t := range w.local.close() {
+		w.pool.push(t)
+	}
+}
+
 // stop stops a worker
 func (w *worker) stop() {
 	close(w.quit)
@@ -76,18 +224,131 @@ func (w *worker) stop() {
 
 // A WorkPool is an abstraction of a set of workers that manages the creation, scheduling, and destruction of workers.
 type WorkPool struct {
-	minWorkers     int
-	maxWorkers     int
+	// minWorkers and maxWorkers can be changed at runtime, always access them atomically
+	minWorkers     int32
+	maxWorkers     int32
 	currentWorkers int32
-	taskQueue      chan Task
+	// slots limits the number of queued tasks, a slot is taken on submission
+	// and released when a worker takes the task out of a local queue
+	slots     *semaphore
+	queueSize int
+	// admitMu is held exclusively by SubmitBatch so that a batch is admitted atomically
+	admitMu sync.RWMutex
+	// workers is guarded by mu, while snapshot is a copy of it for the lock-free hot path
 	workers        []*worker
+	snapshot       atomic.Pointer[[]*worker]
+	nextWorkerID   int32
+	nextPush       uint32
+	idle           int32
 	metrics        *PoolMetrics
 	adjustInterval time.Duration
 	mu             sync.RWMutex
 
-	workerLoads     []int32
 	lastAdjustTime  time.Time
 	adjustThreshold float64
+
+	panicHandler PanicHandler
+	idleTimeout  time.Duration
+	limiter      *rate.Limiter
+
+	metricsSource MetricsSource
+
+	overloadPolicy OverloadPolicy
+	maxRetries     int
+	dlq            DeadLetterQueue
+
+	tracer trace.Tracer
+	hooks  Hooks
+	// notify wakes up any parked worker to steal tasks from busy ones
+	notify chan struct{}
+	// queue is the optional task queue backend
+	queue TaskQueue
+	dedup dedup
+
+	// task execution counters, see runTask
+	running      int64
+	completed    uint64
+	succeeded    uint64
+	totalLatency int64
+
+	// resumeCh is non-nil while the pool is paused and is closed on Resume
+	resumeCh chan struct{}
+	pauseMu  sync.Mutex
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+
+	sched     *scheduler
+	schedOnce sync.Once
+
+	lanes     []chan Task
+	lanesOnce sync.Once
+}
+
+// WithMinWorkers sets the number of workers the pool starts with and never shrinks below.
+func WithMinWorkers(n int) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.minWorkers = int32(n)
+	}
+}
+
+// WithMaxWorkers sets the maximum number of workers the pool can scale up to.
+// It is raised to the minimum number of workers if it is smaller.
+func WithMaxWorkers(n int) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.maxWorkers = int32(n)
+	}
+}
+
+// WithQueueSize sets the capacity of the task queue.
+// Sizes below 1 are raised to 1, as tasks always pass through the queue on their way to a worker.
+func WithQueueSize(size int) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.queueSize = size
+	}
+}
+
+// WithAdjustInterval sets the interval of updating the metrics and adjusting the number of workers.
+func WithAdjustInterval(d time.Duration) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.adjustInterval = d
+	}
+}
+
+// WithAdjustThreshold sets the queue usage above which the pool scales up, 0.8 by default.
+func WithAdjustThreshold(threshold float64) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.adjustThreshold = threshold
+	}
+}
+
+// WithPanicHandler sets the handler called when a Task panics.
+// By default, panics are recovered and silently dropped.
+func WithPanicHandler(h PanicHandler) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.panicHandler = h
+	}
+}
+
+// WithIdleTimeout makes a worker exit once it has been idle for longer than d,
+// so that the pool shrinks back toward minWorkers between traffic bursts.
+// By default, idle workers are only reclaimed by the periodic adjustment.
+func WithIdleTimeout(d time.Duration) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.idleTimeout = d
+	}
+}
+
+// WithRateLimit limits the rate at which tasks are started to tasksPerSecond,
+// allowing bursts of up to burst tasks, so that bursty producers can't overwhelm
+// downstream dependencies even when workers are available.
+func WithRateLimit(tasksPerSecond float64, burst int) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		if burst < 1 {
+			burst = 1
+		}
+		p.limiter = rate.NewLimiter(rate.Limit(tasksPerSecond), burst)
+	}
 }
 
 // PoolMetrics represent the load metrics of the workers in a pool
@@ -98,119 +359,357 @@ type PoolMetrics struct {
 	idleWorkers    float64
 	cpuUsage       float64
 	memoryUsage    float64
-	avgLatency     float64
+	avgLatency     float64 // in nanoseconds
 	successRate    float64
 	lastAdjustTime time.Time
 }
 
-func NewWorkPool(minWorkers, maxWorkers int, queueSize int) *WorkPool {
+// NewWorkPool creates a pool with the given size, it is a shorthand for
+// New(WithMinWorkers(minWorkers), WithMaxWorkers(maxWorkers), WithQueueSize(queueSize), opts...).
+func NewWorkPool(minWorkers, maxWorkers int, queueSize int, opts ...option.Option[WorkPool]) *WorkPool {
+	return New(append([]option.Option[WorkPool]{
+		WithMinWorkers(minWorkers),
+		WithMaxWorkers(maxWorkers),
+		WithQueueSize(queueSize),
+	}, opts...)...)
+}
+
+// New creates a pool configured by opts.
+// By default, the pool starts with 1 worker, scales up to runtime.NumCPU() workers,
+// and buffers up to 1024 tasks.
+func New(opts ...option.Option[WorkPool]) *WorkPool {
 	pool := &WorkPool{
-		minWorkers:      minWorkers,
-		maxWorkers:      maxWorkers,
-		currentWorkers:  int32(minWorkers),
-		taskQueue:       make(chan Task, queueSize),
-		workers:         make([]*worker, 0, maxWorkers),
+		minWorkers:      defaultMinWorkers,
+		maxWorkers:      int32(runtime.NumCPU()),
+		queueSize:       defaultQueueSize,
 		metrics:         &PoolMetrics{lastAdjustTime: time.Now()},
-		adjustInterval:  time.Second * 5,
-		workerLoads:     make([]int32, maxWorkers),
+		adjustInterval:  defaultAdjustInterval,
 		adjustThreshold: 0.8, // Trigger adjustment at 80% load, also allows user decision making
+		closeCh:         make(chan struct{}),
+		metricsSource:   &processMetricsSource{},
+		notify:          make(chan struct{}, 1),
+	}
+	option.Apply(pool, opts...)
+
+	if pool.maxWorkers < 1 {
+		pool.maxWorkers = 1
 	}
+	if pool.maxWorkers < pool.minWorkers {
+		pool.maxWorkers = pool.minWorkers
+	}
+	if pool.queueSize < 1 {
+		pool.queueSize = 1
+	}
+	pool.slots = newSemaphore(pool.queueSize)
+	pool.workers = make([]*worker, 0, pool.maxWorkers)
 
 	// Initially start only the smallest worker thread to avoid wasting resources.
 	// Can be expanded through later asynchronous detection
-	for i := 0; i < minWorkers; i++ {
-		w := newWorker(i)
-		pool.workers = append(pool.workers, w)
-		w.start()
-	}
+	pool.mu.Lock()
+	pool.addWorkers(int(pool.minWorkers))
+	pool.mu.Unlock()
 
 	// Start the dynamic adjustment co-process
 	go pool.adjustWorkers()
 
-	// Start the Task Distribution Concatenation
-	go pool.dispatch()
+	if pool.queue != nil {
+		go pool.feed()
+	}
 
 	return pool
 }
 
-// dispatch is responsible for distributing tasks
-// and dynamically determining the load on the worker to balance after load balancing
-// (since the Client has already done something similar by picking the Server
-// to send the request through a load balancing policy).
-func (p *WorkPool) dispatch() {
-	for t := range p.taskQueue {
-		workerIndex := p.selectWorker()
-		if workerIndex >= 0 {
-			p.mu.RLock()
-			if workerIndex < len(p.workers) {
-				w := p.workers[workerIndex]
-				select {
-				case w.tasks <- t:
-					atomic.AddInt32(&p.workerLoads[workerIndex], 1)
-					continue
-				default:
-					// The worker thread is busy, move on to the next one.
-				}
+// Submit puts t into the task queue, blocking until there is room in the queue,
+// ctx is done or the pool is closed.
+// If the pool uses another policy than the default OverloadBlock,
+// Submit never blocks on a full queue and applies the policy instead.
+func (p *WorkPool) Submit(ctx context.Context, t Task) error {
+	if p.queue != nil {
+		return p.enqueue(ctx, t)
+	}
+	return p.submitInMemory(ctx, t)
+}
+
+// submitInMemory traces t and puts it into the in-memory task queue,
+// bypassing the task queue backend.
+func (p *WorkPool) submitInMemory(ctx context.Context, t Task) error {
+	t = p.traceTask(ctx, t)
+	err := p.submit(ctx, t)
+	if err != nil {
+		abortTrace(t, err)
+	}
+	return err
+}
+
+func (p *WorkPool) submit(ctx context.Context, t Task) error {
+	// Check first so that a closed pool never accepts new tasks,
+	// even if the queue still has room.
+	select {
+	case <-p.closeCh:
+		return ErrPoolClosed
+	default:
+	}
+
+	p.scaleUpIfBusy()
+	if p.overloadPolicy == OverloadSpawn && p.saturated() && !p.Paused() {
+		// All workers are busy, deal with it directly
+		go func() {
+			if !p.waitRate() {
+				discard(t, ErrPoolClosed)
+				return
 			}
-			p.mu.RUnlock()
+			p.runTask(noWorker, t)
+		}()
+		return nil
+	}
+
+	p.admitMu.RLock()
+	if p.overloadPolicy != OverloadSpawn && p.overloadPolicy != OverloadBlock {
+		if p.slots.tryAcquire(1) {
+			p.push(t)
+			p.admitMu.RUnlock()
+			return nil
 		}
+		p.admitMu.RUnlock()
+		return p.handleFullQueue(ctx, t)
+	}
 
-		// If all workers are busy, use the fallback policy
-		p.handleOverload(t)
+	defer p.admitMu.RUnlock()
+	if err := p.slots.acquire(ctx, p.closeCh); err != nil {
+		return err
 	}
+	p.push(t)
+	return nil
 }
 
-// selectWorker dynamically selects the optimal executing worker
-// from the load of the workers recorded in real time.
-func (p *WorkPool) selectWorker() int {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+// TrySubmit puts t into the task queue without blocking.
+// It returns false if the queue is full or the pool is closed,
+// leaving the caller to decide how to apply backpressure.
+func (p *WorkPool) TrySubmit(t Task) bool {
+	if p.queue != nil {
+		return p.enqueue(context.Background(), t) == nil
+	}
+	select {
+	case <-p.closeCh:
+		return false
+	default:
+	}
 
-	if len(p.workers) == 0 {
-		return -1
+	p.scaleUpIfBusy()
+	t = p.traceTask(context.Background(), t)
+	p.admitMu.RLock()
+	defer p.admitMu.RUnlock()
+	if !p.slots.tryAcquire(1) {
+		abortTrace(t, ErrPoolOverloaded)
+		return false
 	}
+	p.push(t)
+	return true
+}
+
+// push puts t into the local queue of a worker chosen in round-robin,
+// the caller must have taken a slot for t.
+// If the chosen worker is busy, a parked worker is notified to steal the task.
+func (p *WorkPool) push(t Task) {
+	for {
+		ws := p.loadWorkers()
+		n := uint32(len(ws))
+		start := atomic.AddUint32(&p.nextPush, 1)
+		for i := uint32(0); i < n; i++ {
+			w := ws[(start+i)%n]
+			if !w.local.pushBack(t) {
+				// The worker is exiting
+				continue
+			}
+			w.wake()
+			if atomic.LoadInt32(&w.parked) == 0 {
+				select {
+				case p.notify <- struct{}{}:
+				default:
+				}
+			}
+			return
+		}
 
-	// Find the least loaded worker thread
-	minLoad := int32(math.MaxInt32)
-	selectedIndex := -1
+		// All the workers are exiting, wait for the new snapshot
+		select {
+		case <-p.closeCh:
+			discard(t, ErrPoolClosed)
+			return
+		default:
+		}
+		if n == 0 {
+			p.quickScaleUp()
+		}
+		runtime.Gosched()
+	}
+}
 
-	for i, load := range p.workerLoads {
-		if i >= len(p.workers) {
-			break
+// steal takes a task from the back of the local queue of another worker.
+func (p *WorkPool) steal(thief *worker) (Task, bool) {
+	ws := p.loadWorkers()
+	n := len(ws)
+	if n == 0 {
+		return nil, false
+	}
+	start := rand.IntN(n)
+	for i := 0; i < n; i++ {
+		w := ws[(start+i)%n]
+		if w == thief {
+			continue
 		}
-		if load < minLoad {
-			minLoad = load
-			selectedIndex = i
+		if t, ok := w.local.popBack(); ok {
+			return t, true
 		}
 	}
+	return nil, false
+}
+
+// loadWorkers returns the current workers without locking.
+func (p *WorkPool) loadWorkers() []*worker {
+	if ws := p.snapshot.Load(); ws != nil {
+		return *ws
+	}
+	return nil
+}
 
-	return selectedIndex
+// publishWorkers updates the snapshot of workers, the caller must hold mu.
+func (p *WorkPool) publishWorkers() {
+	ws := make([]*worker, len(p.workers))
+	copy(ws, p.workers)
+	p.snapshot.Store(&ws)
 }
 
-// handleOverload handles the state where all workers are busy,
-// determines whether to expand the queue based on the current load,
-// and if the expansion is successful, uses the expanded worker to handle it,
-// otherwise it directly tries to start a new goroutine to execute the task.
-func (p *WorkPool) handleOverload(t Task) {
-	if p.metrics.queueUsage > p.adjustThreshold {
+// saturated reports whether all workers are busy and the pool can't scale up anymore.
+func (p *WorkPool) saturated() bool {
+	return atomic.LoadInt32(&p.idle) == 0 && atomic.LoadInt32(&p.currentWorkers) >= atomic.LoadInt32(&p.maxWorkers)
+}
+
+// scaleUpIfBusy scales up the pool when tasks are waiting and no worker is idle.
+// It only takes the lock when the pool actually needs to grow.
+func (p *WorkPool) scaleUpIfBusy() {
+	if p.slots.len() > 0 && atomic.LoadInt32(&p.idle) == 0 &&
+		atomic.LoadInt32(&p.currentWorkers) < atomic.LoadInt32(&p.maxWorkers) {
 		p.quickScaleUp()
 	}
+}
+
+// waitRate blocks until the rate limiter allows another task to start.
+// It returns false if the pool is closed while waiting.
+func (p *WorkPool) waitRate() bool {
+	if p.limiter == nil {
+		return true
+	}
+	r := p.limiter.Reserve()
+	d := r.Delay()
+	if d == 0 {
+		return true
+	}
 
-	p.mu.RLock()
-	for i, w := range p.workers {
-		select {
-		case w.tasks <- t:
-			atomic.AddInt32(&p.workerLoads[i], 1)
-			p.mu.RUnlock()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-p.closeCh:
+		r.Cancel()
+		return false
+	}
+}
+
+// runTask runs t with panic protection and reports panics to the PanicHandler.
+// workerID is the id of the worker running t, or noWorker.
+func (p *WorkPool) runTask(workerID int, t Task) error {
+	if p.hooks.OnTaskStart != nil {
+		p.hooks.OnTaskStart(workerID, unwrapTask(t))
+	}
+	atomic.AddInt64(&p.running, 1)
+	start := time.Now()
+	err := p.execute(context.Background(), t)
+	elapsed := time.Since(start)
+	atomic.AddInt64(&p.totalLatency, int64(elapsed))
+	if err == nil {
+		atomic.AddUint64(&p.succeeded, 1)
+	}
+	atomic.AddUint64(&p.completed, 1)
+	atomic.AddInt64(&p.running, -1)
+	if p.hooks.OnTaskDone != nil {
+		p.hooks.OnTaskDone(workerID, unwrapTask(t), err, elapsed)
+	}
+	return err
+}
+
+// completer is implemented by internal tasks that need to be notified of their final result.
+type completer interface {
+	complete(err error)
+}
+
+// unwrapper is implemented by internal tasks wrapping the tasks submitted by users.
+type unwrapper interface {
+	unwrap() Task
+}
+
+// unwrapTask returns the task submitted by the user,
+// which is what PanicHandler and DeadLetterQueue should see.
+func unwrapTask(t Task) Task {
+	for {
+		u, ok := t.(unwrapper)
+		if !ok {
+			return t
+		}
+		t = u.unwrap()
+	}
+}
+
+// discard completes t with err without running it.
+// Tasks popped from the task queue backend are not acknowledged, so that they are delivered again.
+func discard(t Task, err error) {
+	for u := t; ; {
+		if _, ok := u.(*deliveryTask); ok {
+			abortTrace(t, err)
 			return
-		default:
-			continue
 		}
+		w, ok := u.(unwrapper)
+		if !ok {
+			break
+		}
+		u = w.unwrap()
+	}
+	if c, ok := t.(completer); ok {
+		c.complete(err)
 	}
-	p.mu.RUnlock()
+}
 
-	// If still unassigned, deal with it directly
-	go t.Run(context.Background())
+// execute runs t, retrying up to maxRetries times if it fails,
+// and pushes it to the dead letter queue once the retries are exhausted.
+// A task that panics is never retried, so its side effects are not repeated.
+func (p *WorkPool) execute(ctx context.Context, t Task) error {
+	var (
+		err      error
+		panicErr *PanicError
+	)
+	attempts := 0
+	for attempts <= p.maxRetries {
+		attempts++
+		tw := &taskWrapper{t: t, panicHandler: p.panicHandler}
+		if err = tw.Run(ctx); err == nil || ctx.Err() != nil || errors.As(err, &panicErr) {
+			break
+		}
+	}
+
+	if err != nil && p.dlq != nil {
+		// There is nowhere else to report the error of the DLQ itself
+		_ = p.dlq.Push(DeadLetter{
+			Task:     unwrapTask(t),
+			Err:      err,
+			Attempts: attempts,
+			FailedAt: time.Now(),
+		})
+	}
+	if c, ok := t.(completer); ok {
+		c.complete(err)
+	}
+	return err
 }
 
 // quickScaleUp is an emergency braking strategy
@@ -219,25 +718,83 @@ func (p *WorkPool) handleOverload(t Task) {
 // while staying within the maximum tolerable number of workers when unexpected high concurrency traffic hits.
 func (p *WorkPool) quickScaleUp() {
 	currentWorkers := int(atomic.LoadInt32(&p.currentWorkers))
-	if currentWorkers >= p.maxWorkers {
+	if currentWorkers >= int(atomic.LoadInt32(&p.maxWorkers)) {
 		return
 	}
 
-	// Rapidly increase work threads by 20%
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Rapidly increase work threads by 20%, at least by one
 	targetWorkers := int(float64(currentWorkers) * 1.2)
-	if targetWorkers > p.maxWorkers {
-		targetWorkers = p.maxWorkers
+	if targetWorkers <= currentWorkers {
+		targetWorkers = currentWorkers + 1
+	}
+	if maxWorkers := int(p.maxWorkers); targetWorkers > maxWorkers {
+		targetWorkers = maxWorkers
 	}
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	// Re-check under the lock, others may have scaled up in the meantime
+	p.addWorkers(targetWorkers - len(p.workers))
+}
 
-	for i := currentWorkers; i < targetWorkers; i++ {
-		w := newWorker(i)
+// addWorkers starts n new workers, the caller must hold mu.
+func (p *WorkPool) addWorkers(n int) {
+	if n <= 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		w := newWorker(p)
 		p.workers = append(p.workers, w)
 		w.start()
 		atomic.AddInt32(&p.currentWorkers, 1)
 	}
+	p.publishWorkers()
+}
+
+// removeWorkers stops the last n workers, their queued tasks are given to the others.
+// The caller must hold mu.
+func (p *WorkPool) removeWorkers(n int) {
+	if n > len(p.workers) {
+		n = len(p.workers)
+	}
+	if n <= 0 {
+		return
+	}
+	removed := p.workers[len(p.workers)-n:]
+	p.workers = p.workers[:len(p.workers)-n]
+	atomic.AddInt32(&p.currentWorkers, -int32(n))
+	// Publish first, so that no new task is pushed to the removed workers
+	p.publishWorkers()
+	for _, w := range removed {
+		w.stop()
+	}
+}
+
+// retire removes an idle worker from the pool unless the pool is already at minWorkers.
+// It reports whether the worker has been removed and should exit.
+func (p *WorkPool) retire(w *worker) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.workers) <= int(p.minWorkers) {
+		return false
+	}
+
+	for i, cur := range p.workers {
+		if cur != w {
+			continue
+		}
+		// Tasks may have been pushed just before the timer fired
+		if !w.local.closeIfEmpty() {
+			return false
+		}
+		p.workers = append(p.workers[:i], p.workers[i+1:]...)
+		atomic.AddInt32(&p.currentWorkers, -1)
+		p.publishWorkers()
+		return true
+	}
+	return false
 }
 
 // adjustWorkers asynchronous policy to dynamically monitor and update the status of each worker,
@@ -246,40 +803,47 @@ func (p *WorkPool) adjustWorkers() {
 	ticker := time.NewTicker(p.adjustInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		p.updateMetrics()
-		p.adjustWorkerCount()
+	for {
+		select {
+		case <-ticker.C:
+			p.updateMetrics()
+			p.adjustWorkerCount()
+		case <-p.closeCh:
+			return
+		}
 	}
 }
 
 // updateMetrics Timed task to update worker load metrics for daily fine-tuning.
 func (p *WorkPool) updateMetrics() {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
+	// Sample outside the lock, reading system resources may be slow
+	usage, usageErr := p.metricsSource.Sample()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
 	// Update queue utilization
-	queueLen := len(p.taskQueue)
-	queueCap := cap(p.taskQueue)
-	p.metrics.queueUsage = float64(queueLen) / float64(queueCap)
-
-	// Update a worker thread load
-	totalLoad := int32(0)
-	for i := range p.workerLoads {
-		if i < len(p.workers) {
-			load := atomic.LoadInt32(&p.workerLoads[i])
-			totalLoad += load
-		}
+	if queueCap := p.slots.cap(); queueCap > 0 {
+		p.metrics.queueUsage = float64(p.slots.len()) / float64(queueCap)
 	}
 
+	// Update the ratio of idle workers
 	if len(p.workers) > 0 {
-		p.metrics.idleWorkers = 1.0 - float64(totalLoad)/float64(len(p.workers))
+		p.metrics.idleWorkers = float64(atomic.LoadInt32(&p.idle)) / float64(len(p.workers))
+	}
+
+	// Update system resource utilization, keep the last values if sampling fails
+	if usageErr == nil {
+		p.metrics.cpuUsage = usage.CPU
+		p.metrics.memoryUsage = usage.Memory
 	}
 
-	// Update system resource utilization
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-	p.metrics.cpuUsage = float64(m.Sys) / float64(runtime.NumCPU()*1024*1024)
-	p.metrics.memoryUsage = float64(m.Alloc) / float64(m.Sys)
+	// Update task execution metrics
+	if completed := atomic.LoadUint64(&p.completed); completed > 0 {
+		p.metrics.avgLatency = float64(atomic.LoadInt64(&p.totalLatency)) / float64(completed)
+		p.metrics.successRate = float64(atomic.LoadUint64(&p.succeeded)) / float64(completed)
+	}
+	p.metrics.lastAdjustTime = time.Now()
 }
 
 // adjustWorkerCount is a daily adjustment strategy, unlike quickScaleUp,
@@ -298,46 +862,52 @@ func (p *WorkPool) adjustWorkerCount() {
 		targetWorkers = int(float64(currentWorkers) * 0.8)
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	// Ensure that the minimum and maximum ranges
-	if targetWorkers < p.minWorkers {
-		targetWorkers = p.minWorkers
-	} else if targetWorkers > p.maxWorkers {
-		targetWorkers = p.maxWorkers
+	p.resizeWorkers(targetWorkers)
+}
+
+// resizeWorkers adds or removes workers to reach targetWorkers
+// within the range of minWorkers and maxWorkers, the caller must hold mu.
+func (p *WorkPool) resizeWorkers(targetWorkers int) {
+	if minWorkers := int(p.minWorkers); targetWorkers < minWorkers {
+		targetWorkers = minWorkers
+	} else if maxWorkers := int(p.maxWorkers); targetWorkers > maxWorkers {
+		targetWorkers = maxWorkers
 	}
 
 	// If adjustments are needed
-	if targetWorkers != currentWorkers {
-		p.mu.Lock()
-		defer p.mu.Unlock()
-
+	if currentWorkers := len(p.workers); targetWorkers != currentWorkers {
 		if targetWorkers > currentWorkers {
 			// Add worker threads
-			for i := currentWorkers; i < targetWorkers; i++ {
-				w := newWorker(i)
-				p.workers = append(p.workers, w)
-				w.start()
-				atomic.AddInt32(&p.currentWorkers, 1)
-			}
+			p.addWorkers(targetWorkers - len(p.workers))
 		} else {
 			// Reduce work threads
-			for i := currentWorkers - 1; i >= targetWorkers; i-- {
-				if i < len(p.workers) {
-					p.workers[i].stop()
-					p.workers = p.workers[:i]
-					atomic.AddInt32(&p.currentWorkers, -1)
-				}
-			}
+			p.removeWorkers(len(p.workers) - targetWorkers)
 		}
 	}
 }
 
-// stop shuts down the work pool
-func (p *WorkPool) stop() {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+// Close shuts down the pool. Submissions fail with ErrPoolClosed from then on,
+// the workers and background goroutines exit, and the tasks still queued are discarded:
+// waiters such as Group.Wait and Future.Wait get ErrPoolClosed for them,
+// and tasks popped from the task queue backend are left unacknowledged so that they are delivered again.
+// Running tasks are not interrupted. Close can be called more than once.
+func (p *WorkPool) Close() {
+	p.closeOnce.Do(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
 
-	for _, w := range p.workers {
-		w.stop()
-	}
-	close(p.taskQueue)
+		close(p.closeCh)
+		for _, w := range p.workers {
+			// Close the local queue before stopping the worker, so that it hands over nothing
+			for _, t := range w.local.close() {
+				p.slots.release()
+				discard(t, ErrPoolClosed)
+			}
+			w.stop()
+		}
+	})
 }
@@ -0,0 +1,333 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+type taskFunc func(ctx context.Context) error
+
+func (f taskFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestTaskWrapper_Run(t *testing.T) {
+	testCases := []struct {
+		name      string
+		task      Task
+		wantPanic bool
+		wantErr   error
+	}{
+		{
+			name: "normal",
+			task: taskFunc(func(ctx context.Context) error {
+				return nil
+			}),
+		},
+		{
+			name: "return error",
+			task: taskFunc(func(ctx context.Context) error {
+				return errors.New("mock error")
+			}),
+			wantErr: errors.New("mock error"),
+		},
+		{
+			name: "panic",
+			task: taskFunc(func(ctx context.Context) error {
+				panic("mock panic")
+			}),
+			wantPanic: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var recovered any
+			tw := &taskWrapper{
+				t: tc.task,
+				panicHandler: func(task Task, r any, stack []byte) {
+					recovered = r
+					assert.NotEmpty(t, stack)
+				},
+			}
+			err := tw.Run(context.Background())
+			if !tc.wantPanic {
+				assert.Equal(t, tc.wantErr, err)
+				assert.Nil(t, recovered)
+				return
+			}
+			assert.ErrorIs(t, err, errTaskRunningPanic)
+			var pe *PanicError
+			assert.True(t, errors.As(err, &pe))
+			assert.Equal(t, "mock panic", pe.Recovered)
+			assert.Equal(t, "mock panic", recovered)
+		})
+	}
+}
+
+func TestWorkPool_PanicHandler(t *testing.T) {
+	ch := make(chan any, 1)
+	p := NewWorkPool(1, 2, 4, WithPanicHandler(func(task Task, r any, stack []byte) {
+		ch <- r
+	}))
+	p.slots.tryAcquire(1)
+	p.push(taskFunc(func(ctx context.Context) error {
+		panic("boom")
+	}))
+
+	select {
+	case r := <-ch:
+		assert.Equal(t, "boom", r)
+	case <-time.After(time.Second):
+		t.Fatal("panic handler was not called")
+	}
+}
+
+func TestWorkPool_IdleTimeout(t *testing.T) {
+	p := NewWorkPool(2, 4, 4, WithIdleTimeout(50*time.Millisecond))
+	p.mu.Lock()
+	p.addWorkers(2)
+	p.mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		return len(p.workers) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&p.currentWorkers))
+
+	// never shrinks below minWorkers
+	time.Sleep(100 * time.Millisecond)
+	p.mu.RLock()
+	assert.Equal(t, 2, len(p.workers))
+	p.mu.RUnlock()
+}
+
+func TestWorkPool_Submit(t *testing.T) {
+	p := NewWorkPool(1, 2, 4)
+	done := make(chan struct{})
+	err := p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+		close(done)
+		return nil
+	}))
+	assert.NoError(t, err)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task was not executed")
+	}
+
+	p.Close()
+	err = p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+		return nil
+	}))
+	assert.Equal(t, ErrPoolClosed, err)
+
+	// queue is full and nobody consumes it
+	full := NewWorkPool(1, 1, 1, WithOverloadPolicy(OverloadBlock))
+	defer full.Close()
+	full.Pause()
+	assert.NoError(t, full.Submit(context.Background(), taskFunc(func(ctx context.Context) error { return nil })))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = full.Submit(ctx, taskFunc(func(ctx context.Context) error { return nil }))
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWorkPool_Close(t *testing.T) {
+	p := NewWorkPool(1, 1, 4)
+	p.Pause()
+	g := p.NewGroup(context.Background())
+	var ran int32
+	for i := 0; i < 3; i++ {
+		g.Go(taskFunc(func(ctx context.Context) error {
+			atomic.AddInt32(&ran, 1)
+			return nil
+		}))
+	}
+
+	// queued tasks are discarded, and the group doesn't wait for them forever
+	p.Close()
+	err := g.Wait()
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&ran))
+
+	// closing twice is fine
+	p.Close()
+	assert.Equal(t, ErrPoolClosed, p.Submit(context.Background(), taskFunc(func(ctx context.Context) error { return nil })))
+}
+
+func TestWorkPool_ZeroQueueSize(t *testing.T) {
+	p := NewWorkPool(1, 1, 0)
+	defer p.Close()
+	assert.Equal(t, 1, p.slots.cap())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var cnt int32
+	task := taskFunc(func(ctx context.Context) error {
+		atomic.AddInt32(&cnt, 1)
+		return nil
+	})
+	assert.NoError(t, p.Submit(ctx, task))
+	assert.Eventually(t, func() bool {
+		return p.TrySubmit(task)
+	}, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cnt) == 2
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, p.SetQueueSize(0))
+	assert.Equal(t, 1, p.slots.cap())
+}
+
+func TestWorkPool_CloseWhileRateLimited(t *testing.T) {
+	p := NewWorkPool(1, 1, 4, WithRateLimit(0.001, 1))
+	g := p.NewGroup(context.Background())
+	for i := 0; i < 2; i++ {
+		g.Go(taskFunc(func(ctx context.Context) error { return nil }))
+	}
+	// the second task has been taken by the worker, which waits for the rate limiter
+	assert.Eventually(t, func() bool {
+		return p.Stats().CompletedTasks == 1 && p.slots.len() == 0
+	}, time.Second, time.Millisecond)
+
+	p.Close()
+	assert.ErrorIs(t, g.Wait(), ErrPoolClosed)
+}
+
+func TestWorkPool_TrySubmit(t *testing.T) {
+	p := NewWorkPool(1, 1, 1)
+	p.Pause()
+	task := taskFunc(func(ctx context.Context) error { return nil })
+	assert.True(t, p.TrySubmit(task))
+	// queue is full
+	assert.False(t, p.TrySubmit(task))
+
+	p.Resume()
+	assert.Eventually(t, func() bool {
+		return p.Stats().CompletedTasks == 1
+	}, time.Second, time.Millisecond)
+	p.Close()
+	assert.False(t, p.TrySubmit(task))
+}
+
+func TestWorkPool_RateLimit(t *testing.T) {
+	p := NewWorkPool(2, 4, 8, WithRateLimit(50, 1))
+	defer p.Close()
+
+	var cnt int32
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		err := p.Submit(context.Background(), taskFunc(func(ctx context.Context) error {
+			atomic.AddInt32(&cnt, 1)
+			return nil
+		}))
+		assert.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cnt) == 5
+	}, time.Second, 5*time.Millisecond)
+	// the first task starts immediately, the rest wait 20ms each
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []option.Option[WorkPool]
+
+		wantMin      int
+		wantMax      int
+		wantQueue    int
+		wantInterval time.Duration
+		wantPolicy   OverloadPolicy
+	}{
+		{
+			name:         "default",
+			wantMin:      1,
+			wantMax:      runtime.NumCPU(),
+			wantQueue:    1024,
+			wantInterval: 5 * time.Second,
+		},
+		{
+			name: "options",
+			opts: []option.Option[WorkPool]{
+				WithMinWorkers(2),
+				WithMaxWorkers(8),
+				WithQueueSize(16),
+				WithAdjustInterval(time.Minute),
+				WithOverloadPolicy(OverloadReject),
+			},
+			wantMin:      2,
+			wantMax:      8,
+			wantQueue:    16,
+			wantInterval: time.Minute,
+			wantPolicy:   OverloadReject,
+		},
+		{
+			name: "max less than min",
+			opts: []option.Option[WorkPool]{
+				WithMinWorkers(4),
+				WithMaxWorkers(2),
+			},
+			wantMin:      4,
+			wantMax:      4,
+			wantQueue:    1024,
+			wantInterval: 5 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(tc.opts...)
+			defer p.Close()
+			stats := p.Stats()
+			assert.Equal(t, tc.wantMin, stats.MinWorkers)
+			assert.Equal(t, tc.wantMax, stats.MaxWorkers)
+			assert.Equal(t, tc.wantMin, stats.Workers)
+			assert.Equal(t, tc.wantQueue, stats.QueueCapacity)
+			assert.Equal(t, tc.wantInterval, p.adjustInterval)
+			assert.Equal(t, tc.wantPolicy, p.overloadPolicy)
+			assert.Len(t, p.loadWorkers(), tc.wantMin)
+		})
+	}
+}
+
+func TestWorkPool_Steal(t *testing.T) {
+	p := NewWorkPool(2, 2, 4, WithOverloadPolicy(OverloadBlock))
+	defer p.Close()
+
+	block := make(chan struct{})
+	defer close(block)
+	done := make(chan struct{})
+	// both tasks go to the same worker which is blocked by the first one,
+	// so the second one has to be stolen
+	w := p.loadWorkers()[0]
+	p.slots.tryAcquire(1)
+	w.local.pushBack(taskFunc(func(ctx context.Context) error {
+		<-block
+		return nil
+	}))
+	p.slots.tryAcquire(1)
+	w.local.pushBack(taskFunc(func(ctx context.Context) error {
+		close(done)
+		return nil
+	}))
+	w.wake()
+	p.notify <- struct{}{}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task was not stolen")
+	}
+}
@@ -0,0 +1,98 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+const instrumentationName = "github.com/ecloudclub/zkit/pool"
+
+// WithTracerProvider enables OpenTelemetry tracing of the task lifecycle.
+// A "pool.task" span is started when a task is submitted and ended when it completes,
+// with a "pool.task.run" child span for every execution.
+// The span of the submitting context is the parent of "pool.task",
+// and the context with the "pool.task.run" span is passed to Task.Run.
+func WithTracerProvider(tp trace.TracerProvider) option.Option[WorkPool] {
+	return func(p *WorkPool) {
+		p.tracer = tp.Tracer(instrumentationName)
+	}
+}
+
+// tracedTask carries the context of the submitter and the span covering the whole lifecycle of the task.
+type tracedTask struct {
+	t          Task
+	tracer     trace.Tracer
+	ctx        context.Context
+	span       trace.Span
+	enqueuedAt time.Time
+	once       sync.Once
+}
+
+// traceTask wraps t so that its lifecycle is traced. It returns t itself if tracing is disabled.
+func (p *WorkPool) traceTask(ctx context.Context, t Task) Task {
+	if p.tracer == nil {
+		return t
+	}
+	// The task usually outlives the submitter, so keep only the values of ctx
+	ctx, span := p.tracer.Start(context.WithoutCancel(ctx), "pool.task")
+	return &tracedTask{
+		t:          t,
+		tracer:     p.tracer,
+		ctx:        ctx,
+		span:       span,
+		enqueuedAt: time.Now(),
+	}
+}
+
+func (tt *tracedTask) Run(_ context.Context) error {
+	start := time.Now()
+	ctx, span := tt.tracer.Start(tt.ctx, "pool.task.run", trace.WithAttributes(
+		attribute.Int64("pool.task.queue_wait_ms", start.Sub(tt.enqueuedAt).Milliseconds()),
+	))
+	defer func() {
+		span.SetAttributes(attribute.Int64("pool.task.execution_ms", time.Since(start).Milliseconds()))
+		span.End()
+	}()
+
+	err := tt.t.Run(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (tt *tracedTask) unwrap() Task {
+	return tt.t
+}
+
+func (tt *tracedTask) complete(err error) {
+	tt.end(err)
+	if c, ok := tt.t.(completer); ok {
+		c.complete(err)
+	}
+}
+
+func (tt *tracedTask) end(err error) {
+	tt.once.Do(func() {
+		if err != nil {
+			tt.span.RecordError(err)
+			tt.span.SetStatus(codes.Error, err.Error())
+		}
+		tt.span.End()
+	})
+}
+
+// abortTrace ends the span of t if it has not been accepted by the pool.
+func abortTrace(t Task, err error) {
+	if tt, ok := t.(*tracedTask); ok {
+		tt.end(err)
+	}
+}
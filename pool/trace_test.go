@@ -0,0 +1,78 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWorkPool_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	p := NewWorkPool(1, 2, 4, WithTracerProvider(tp))
+	defer p.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	var runSpan trace.SpanContext
+	err := p.Submit(ctx, taskFunc(func(ctx context.Context) error {
+		runSpan = trace.SpanContextFromContext(ctx)
+		return errors.New("mock error")
+	}))
+	require.NoError(t, err)
+	parent.End()
+
+	assert.Eventually(t, func() bool {
+		return len(recorder.Ended()) == 3
+	}, time.Second, time.Millisecond)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan, 3)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	task, run := spans["pool.task"], spans["pool.task.run"]
+	require.NotNil(t, task)
+	require.NotNil(t, run)
+	assert.Equal(t, parent.SpanContext().SpanID(), task.Parent().SpanID())
+	assert.Equal(t, task.SpanContext().SpanID(), run.Parent().SpanID())
+	assert.Equal(t, run.SpanContext().SpanID(), runSpan.SpanID())
+	assert.Equal(t, codes.Error, task.Status().Code)
+	assert.Equal(t, codes.Error, run.Status().Code)
+
+	keys := make(map[string]bool)
+	for _, attr := range run.Attributes() {
+		keys[string(attr.Key)] = true
+	}
+	assert.True(t, keys["pool.task.queue_wait_ms"])
+	assert.True(t, keys["pool.task.execution_ms"])
+}
+
+func TestWorkPool_TracingGroup(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	p := NewWorkPool(1, 2, 4, WithTracerProvider(tp))
+	defer p.Close()
+
+	// the completion of the wrapped task must still be reported to the group
+	g := p.NewGroup(context.Background())
+	g.Go(taskFunc(func(ctx context.Context) error { return nil }))
+	assert.NoError(t, g.Wait())
+}
+
+func TestUnwrapTask(t *testing.T) {
+	task := taskFunc(func(ctx context.Context) error { return nil })
+	p := NewWorkPool(1, 2, 4, WithTracerProvider(sdktrace.NewTracerProvider()))
+	defer p.Close()
+	g := p.NewGroup(context.Background())
+	wrapped := p.traceTask(context.Background(), &groupTask{g: g, t: task})
+	assert.NotNil(t, unwrapTask(wrapped))
+	_, ok := unwrapTask(wrapped).(taskFunc)
+	assert.True(t, ok)
+}
@@ -0,0 +1,138 @@
+package reflectx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrNotFunc = errors.New("zkit: 不是函数")
+
+	errorType   = reflect.TypeFor[error]()
+	contextType = reflect.TypeFor[context.Context]()
+)
+
+// Call calls fn with args converted to the types of its parameters by SetValue,
+// e.g. Call(strconv.Itoa, "1") or Call(fn, ctx, map[string]any{...}) for a struct parameter.
+// Variadic functions accept any number of args for the variadic parameter.
+// It validates the number and the types of args instead of panicking like reflect.Value.Call,
+// and returns the results of fn, or an error if fn is not a function or args do not fit.
+func Call(fn any, args ...any) ([]any, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return nil, fmt.Errorf("%w: %T", ErrNotFunc, fn)
+	}
+	in, err := callArgs(fv.Type(), args)
+	if err != nil {
+		return nil, err
+	}
+	out := fv.Call(in)
+	res := make([]any, len(out))
+	for i, v := range out {
+		res[i] = v.Interface()
+	}
+	return res, nil
+}
+
+// callArgs converts args into the parameters of the function type typ.
+func callArgs(typ reflect.Type, args []any) ([]reflect.Value, error) {
+	n := typ.NumIn()
+	if typ.IsVariadic() {
+		if len(args) < n-1 {
+			return nil, fmt.Errorf("zkit: 参数数量错误，至少需要 %d 个，实际为 %d 个", n-1, len(args))
+		}
+	} else if len(args) != n {
+		return nil, fmt.Errorf("zkit: 参数数量错误，需要 %d 个，实际为 %d 个", n, len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var pt reflect.Type
+		if typ.IsVariadic() && i >= n-1 {
+			pt = typ.In(n - 1).Elem()
+		} else {
+			pt = typ.In(i)
+		}
+		v := reflect.New(pt).Elem()
+		if err := convertArg(v, arg); err != nil {
+			return nil, fmt.Errorf("zkit: 第 %d 个参数: %w", i+1, err)
+		}
+		in[i] = v
+	}
+	return in, nil
+}
+
+// convertArg sets arg to v, maps populate struct parameters like MapToStruct.
+func convertArg(v reflect.Value, arg any) error {
+	if m, ok := arg.(map[string]any); ok {
+		t := v.Type()
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			d := &decoder{tag: "json"}
+			return d.decode(v, m)
+		}
+	}
+	return SetValue(v, arg)
+}
+
+// Handler is a function with a uniform signature made by MakeHandler.
+type Handler func(ctx context.Context, args ...any) (any, error)
+
+// MakeHandler wraps fn into a Handler, so that functions of different signatures can be registered
+// in plugin registries or generic dispatchers. fn may take a context.Context as the first parameter,
+// which is passed ctx, and the other parameters are converted from args like Call.
+// fn may return nothing, a result, an error, or a result and an error.
+// It returns an error if fn is not a function or returns otherwise.
+func MakeHandler(fn any) (Handler, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return nil, fmt.Errorf("%w: %T", ErrNotFunc, fn)
+	}
+	typ := fv.Type()
+	withCtx := typ.NumIn() > 0 && typ.In(0) == contextType
+	if typ.NumOut() > 2 || typ.NumOut() == 2 && typ.Out(1) != errorType {
+		return nil, fmt.Errorf("zkit: 函数 %s 的返回值必须是 (T, error)、T、error 或为空", typ)
+	}
+
+	return func(ctx context.Context, args ...any) (any, error) {
+		if withCtx {
+			args = append([]any{ctx}, args...)
+		}
+		in, err := callArgs(typ, args)
+		if err != nil {
+			return nil, err
+		}
+		out := fv.Call(in)
+		switch len(out) {
+		case 0:
+			return nil, nil
+		case 1:
+			if typ.Out(0) == errorType {
+				return nil, errorOf(out[0])
+			}
+			return out[0].Interface(), nil
+		default:
+			return out[0].Interface(), errorOf(out[1])
+		}
+	}, nil
+}
+
+// MustMakeHandler is like MakeHandler but panics if fn is invalid, e.g. for registering at init.
+func MustMakeHandler(fn any) Handler {
+	h, err := MakeHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+func errorOf(v reflect.Value) error {
+	if v.IsNil() {
+		return nil
+	}
+	return v.Interface().(error)
+}
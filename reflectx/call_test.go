@@ -0,0 +1,95 @@
+package reflectx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fn      any
+		args    []any
+		want    []any
+		wantErr string
+	}{
+		{name: "转换参数", fn: strconv.Itoa, args: []any{"42"}, want: []any{"42"}},
+		{name: "多个返回值", fn: strconv.Atoi, args: []any{"42"}, want: []any{42, nil}},
+		{name: "可变参数", fn: func(sep string, nums ...int) string {
+			return strings.Trim(strings.Join(strings.Fields(fmt.Sprint(nums)), sep), "[]")
+		}, args: []any{",", 1, "2", 3.0}, want: []any{"1,2,3"}},
+		{name: "可变参数为空", fn: func(nums ...int) int { return len(nums) }, want: []any{0}},
+		{name: "结构体参数", fn: func(a Address) string { return a.City }, args: []any{map[string]any{"city": "Shanghai"}}, want: []any{"Shanghai"}},
+		{name: "nil 参数", fn: func(p *int) bool { return p == nil }, args: []any{nil}, want: []any{true}},
+		{name: "非函数", fn: 1, wantErr: "zkit: 不是函数: int"},
+		{name: "参数过少", fn: strings.Repeat, args: []any{"a"}, wantErr: "zkit: 参数数量错误，需要 2 个，实际为 1 个"},
+		{name: "可变参数过少", fn: fmt.Sprintf, wantErr: "zkit: 参数数量错误，至少需要 1 个，实际为 0 个"},
+		{name: "类型错误", fn: strconv.Itoa, args: []any{"a"}, wantErr: `zkit: 第 1 个参数: strconv.ParseInt: parsing "a": invalid syntax`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Call(tc.fn, tc.args...)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := Call(nil)
+	assert.ErrorIs(t, err, ErrNotFunc)
+}
+
+type ctxKey struct{}
+
+func TestMakeHandler(t *testing.T) {
+	errFailed := errors.New("failed")
+	ctx := context.WithValue(context.Background(), ctxKey{}, "user-1")
+	testCases := []struct {
+		name    string
+		fn      any
+		args    []any
+		want    any
+		wantErr error
+	}{
+		{name: "无返回值", fn: func(int) {}, args: []any{1}},
+		{name: "结果", fn: func(a, b int) int { return a + b }, args: []any{"1", 2}, want: 3},
+		{name: "错误", fn: func() error { return errFailed }, wantErr: errFailed},
+		{name: "nil 错误", fn: func() error { return nil }},
+		{name: "结果和错误", fn: strconv.Atoi, args: []any{"7"}, want: 7},
+		{name: "context", fn: func(ctx context.Context, greeting string) (string, error) {
+			return greeting + " " + ctx.Value(ctxKey{}).(string), nil
+		}, args: []any{"hello"}, want: "hello user-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := MakeHandler(tc.fn)
+			require.NoError(t, err)
+			got, err := h(ctx, tc.args...)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := MakeHandler(func() (int, int) { return 0, 0 })
+	assert.EqualError(t, err, "zkit: 函数 func() (int, int) 的返回值必须是 (T, error)、T、error 或为空")
+	_, err = MakeHandler("fn")
+	assert.ErrorIs(t, err, ErrNotFunc)
+	assert.Panics(t, func() { MustMakeHandler(1) })
+
+	// 参数错误
+	h := MustMakeHandler(strconv.Itoa)
+	_, err = h(ctx)
+	assert.EqualError(t, err, "zkit: 参数数量错误，需要 1 个，实际为 0 个")
+}
@@ -0,0 +1,169 @@
+package reflectx
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// MergeMaps merges the maps srcs into the map dst points to or is, the later ones overwrite the earlier ones.
+// dst is created if it points to a nil map. The keys and the values of srcs are converted into the types
+// of dst by SetValue, so maps of different types can be merged, e.g. map[string]string into map[string]any.
+func MergeMaps(dst any, srcs ...any) error {
+	// fast path for the most common type
+	if m, ok := dst.(map[string]any); ok && m != nil {
+		if merged := mergeStringMaps(m, srcs); merged {
+			return nil
+		}
+	}
+
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() == reflect.Pointer && !dv.IsNil() && dv.Elem().Kind() == reflect.Map {
+		if dv.Elem().IsNil() {
+			dv.Elem().Set(reflect.MakeMap(dv.Elem().Type()))
+		}
+		dv = dv.Elem()
+	}
+	if dv.Kind() != reflect.Map || dv.IsNil() {
+		return fmt.Errorf("zkit: dst 必须是非 nil 的 map 或指向 map 的指针，实际为 %T", dst)
+	}
+
+	typ := dv.Type()
+	for _, src := range srcs {
+		sv := reflect.ValueOf(src)
+		if !sv.IsValid() {
+			continue
+		}
+		if sv.Kind() != reflect.Map {
+			return fmt.Errorf("zkit: %T 不是 map", src)
+		}
+		iter := sv.MapRange()
+		for iter.Next() {
+			key := reflect.New(typ.Key()).Elem()
+			if err := SetValue(key, iter.Key().Interface()); err != nil {
+				return err
+			}
+			val := reflect.New(typ.Elem()).Elem()
+			if err := SetValue(val, iter.Value().Interface()); err != nil {
+				return fmt.Errorf("%v: %w", iter.Key().Interface(), err)
+			}
+			dv.SetMapIndex(key, val)
+		}
+	}
+	return nil
+}
+
+// mergeStringMaps merges srcs into dst if all of them are map[string]any.
+func mergeStringMaps(dst map[string]any, srcs []any) bool {
+	for _, src := range srcs {
+		if _, ok := src.(map[string]any); !ok && src != nil {
+			return false
+		}
+	}
+	for _, src := range srcs {
+		sm, _ := src.(map[string]any)
+		for k, v := range sm {
+			dst[k] = v
+		}
+	}
+	return true
+}
+
+// KeysOf returns the keys of the map m in no particular order, or nil if m is not a map.
+func KeysOf(m any) []any {
+	if sm, ok := m.(map[string]any); ok {
+		keys := make([]any, 0, len(sm))
+		for k := range sm {
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	mv := reflect.ValueOf(m)
+	if mv.Kind() != reflect.Map {
+		return nil
+	}
+	keys := make([]any, 0, mv.Len())
+	iter := mv.MapRange()
+	for iter.Next() {
+		keys = append(keys, iter.Key().Interface())
+	}
+	return keys
+}
+
+// ToAnySlice copies the elements of the slice or the array v into a []any,
+// e.g. to pass []int to functions taking ...any. It returns an error if v is neither.
+func ToAnySlice(v any) ([]any, error) {
+	switch s := v.(type) {
+	case []any:
+		return slices.Clone(s), nil
+	case []string:
+		return toAny(s), nil
+	case []int:
+		return toAny(s), nil
+	case []int64:
+		return toAny(s), nil
+	}
+
+	sv := reflect.ValueOf(v)
+	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("zkit: %T 不是切片或数组", v)
+	}
+	res := make([]any, sv.Len())
+	for i := range res {
+		res[i] = sv.Index(i).Interface()
+	}
+	return res, nil
+}
+
+func toAny[T any](s []T) []any {
+	res := make([]any, len(s))
+	for i, v := range s {
+		res[i] = v
+	}
+	return res
+}
+
+// IndexOfField returns the index of the first element in the slice of structs, or pointers to them,
+// whose field equals val, or -1 if there is none, e.g. IndexOfField(users, "ID", 1).
+// val is converted into the type of the field by SetValue before comparing, so "1" matches 1 as well.
+// It returns an error if slice is not a slice of structs with the field, or val can't be converted.
+func IndexOfField(slice any, field string, val any) (int, error) {
+	sv := reflect.ValueOf(slice)
+	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+		return -1, fmt.Errorf("zkit: %T 不是切片或数组", slice)
+	}
+	elemType := sv.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return -1, fmt.Errorf("zkit: %T 的元素不是结构体", slice)
+	}
+	sf, ok := elemType.FieldByName(field)
+	if !ok || !sf.IsExported() {
+		return -1, fmt.Errorf("zkit: %s 没有导出的字段 %s", elemType, field)
+	}
+	target := reflect.New(sf.Type).Elem()
+	if err := SetValue(target, val); err != nil {
+		return -1, err
+	}
+
+	for i := 0; i < sv.Len(); i++ {
+		elem := sv.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		fv, err := elem.FieldByIndexErr(sf.Index)
+		if err != nil {
+			continue // nil embedded pointers
+		}
+		if equal(fv, target) {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
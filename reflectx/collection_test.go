@@ -0,0 +1,101 @@
+package reflectx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMaps(t *testing.T) {
+	dst := map[string]any{"a": 1, "b": 2}
+	require.NoError(t, MergeMaps(dst, map[string]any{"b": 3}, nil, map[string]any{"c": 4}))
+	assert.Equal(t, map[string]any{"a": 1, "b": 3, "c": 4}, dst)
+
+	// 不同类型的 map
+	require.NoError(t, MergeMaps(dst, map[string]string{"d": "5"}))
+	assert.Equal(t, map[string]any{"a": 1, "b": 3, "c": 4, "d": "5"}, dst)
+
+	var ports map[string]int
+	require.NoError(t, MergeMaps(&ports, map[string]any{"http": "80"}, map[string]float64{"https": 443}))
+	assert.Equal(t, map[string]int{"http": 80, "https": 443}, ports)
+
+	assert.EqualError(t, MergeMaps(ports, map[string]any{"ssh": "a"}), `ssh: strconv.ParseInt: parsing "a": invalid syntax`)
+	assert.EqualError(t, MergeMaps(ports, []int{1}), "zkit: []int 不是 map")
+	assert.EqualError(t, MergeMaps(map[string]int(nil)), "zkit: dst 必须是非 nil 的 map 或指向 map 的指针，实际为 map[string]int")
+}
+
+func TestKeysOf(t *testing.T) {
+	assert.ElementsMatch(t, []any{"a", "b"}, KeysOf(map[string]any{"a": 1, "b": 2}))
+	assert.ElementsMatch(t, []any{1, 2}, KeysOf(map[int]string{1: "a", 2: "b"}))
+	assert.Empty(t, KeysOf(map[int]string{}))
+	assert.Nil(t, KeysOf([]int{1}))
+}
+
+func TestToAnySlice(t *testing.T) {
+	testCases := []struct {
+		name    string
+		val     any
+		want    []any
+		wantErr string
+	}{
+		{name: "[]any", val: []any{1, "a"}, want: []any{1, "a"}},
+		{name: "[]string", val: []string{"a", "b"}, want: []any{"a", "b"}},
+		{name: "[]int", val: []int{1, 2}, want: []any{1, 2}},
+		{name: "其他切片", val: []float64{1.5}, want: []any{1.5}},
+		{name: "数组", val: [2]bool{true, false}, want: []any{true, false}},
+		{name: "非切片", val: 1, wantErr: "zkit: int 不是切片或数组"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToAnySlice(tc.val)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	// 结果不与 v 共享元素
+	src := []any{1, 2}
+	got, err := ToAnySlice(src)
+	require.NoError(t, err)
+	got[0] = 3
+	assert.Equal(t, []any{1, 2}, src)
+}
+
+func TestIndexOfField(t *testing.T) {
+	users := []User{{Name: "Tom", Base: Base{ID: 1}}, {Name: "Jerry", Base: Base{ID: 2}}}
+	testCases := []struct {
+		name    string
+		slice   any
+		field   string
+		val     any
+		want    int
+		wantErr string
+	}{
+		{name: "找到", slice: users, field: "Name", val: "Jerry", want: 1},
+		{name: "嵌入字段和类型转换", slice: users, field: "ID", val: "2", want: 1},
+		{name: "未找到", slice: users, field: "Name", val: "Spike", want: -1},
+		{name: "指针切片", slice: []*User{nil, &users[0]}, field: "Name", val: "Tom", want: 1},
+		{name: "非切片", slice: users[0], field: "Name", val: "Tom", want: -1, wantErr: "zkit: reflectx.User 不是切片或数组"},
+		{name: "元素不是结构体", slice: []int{1}, field: "Name", val: "Tom", want: -1, wantErr: "zkit: []int 的元素不是结构体"},
+		{name: "字段不存在", slice: users, field: "Email", val: "a", want: -1, wantErr: "zkit: reflectx.User 没有导出的字段 Email"},
+		{name: "值无法转换", slice: users, field: "ID", val: "a", want: -1, wantErr: `strconv.ParseInt: parsing "a": invalid syntax`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := IndexOfField(tc.slice, tc.field, tc.val)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package reflectx
+
+import (
+	"reflect"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// Change is a field changed between two structs.
+type Change struct {
+	// Path is the path to the field, e.g. "Address.City"
+	Path string
+	Old  any
+	New  any
+}
+
+type differ struct {
+	pathTag string
+	ignored map[string]struct{}
+	// visiting holds the pairs of pointers being compared on the current path,
+	// so that the structs referencing themselves are compared once
+	visiting map[visitedPair]struct{}
+}
+
+type visitedPair struct {
+	old, new uintptr
+	typ      reflect.Type
+}
+
+type DiffOption = option.Option[differ]
+
+// WithPathTag names the fields in the paths by the tag, e.g. "json", instead of their Go names.
+func WithPathTag(tag string) DiffOption {
+	return func(d *differ) {
+		d.pathTag = tag
+	}
+}
+
+// WithIgnoreFields skips the fields at paths, e.g. "UpdatedAt" or "Address.Street".
+func WithIgnoreFields(paths ...string) DiffOption {
+	return func(d *differ) {
+		for _, path := range paths {
+			d.ignored[path] = struct{}{}
+		}
+	}
+}
+
+// Diff returns the fields changed from old to new in the order of their declaration,
+// e.g. for the audit logs of entity updates. old and new are structs, or pointers to them, of the same type.
+// Nested structs are compared field by field, and the other fields as a whole by their Equal methods,
+// e.g. time.Time, or reflect.DeepEqual. Unexported fields and the ones tagged `diff:"-"` are skipped.
+// If old and new are not structs of the same type, they are compared as a whole with the path "".
+func Diff(old, new any, opts ...DiffOption) []Change {
+	d := &differ{ignored: make(map[string]struct{}), visiting: make(map[visitedPair]struct{})}
+	option.Apply(d, opts...)
+
+	var changes []Change
+	d.diff(reflect.ValueOf(old), reflect.ValueOf(new), "", &changes)
+	return changes
+}
+
+func (d *differ) diff(old, new reflect.Value, path string, changes *[]Change) {
+	if _, ok := d.ignored[path]; ok && path != "" {
+		return
+	}
+	leave, ok := d.enter(old, new)
+	if !ok {
+		return
+	}
+	defer leave()
+	oldStruct, oldOK := diffStructOf(old)
+	newStruct, newOK := diffStructOf(new)
+	if !oldOK || !newOK || oldStruct.Type() != newStruct.Type() {
+		if !equal(old, new) {
+			*changes = append(*changes, Change{Path: path, Old: interfaceOf(old), New: interfaceOf(new)})
+		}
+		return
+	}
+
+	for _, field := range StructFields(oldStruct.Type(), "diff") {
+		if !field.IsExported() || field.Tag.Ignored {
+			continue
+		}
+		name := field.Name
+		if d.pathTag != "" {
+			if tag := ParseTag(field.StructField.Tag.Get(d.pathTag)); tag.Name != "" && !tag.Ignored {
+				name = tag.Name
+			}
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+		d.diff(oldStruct.FieldByIndex(field.Index), newStruct.FieldByIndex(field.Index), name, changes)
+	}
+}
+
+// enter marks old and new as being compared if they are pointers, and calls leave once they are done.
+// It returns false if they are already being compared on the current path,
+// which is the case when the structs reference themselves, e.g. a parent and its children.
+func (d *differ) enter(old, new reflect.Value) (leave func(), ok bool) {
+	for old.Kind() == reflect.Interface && !old.IsNil() {
+		old = old.Elem()
+	}
+	for new.Kind() == reflect.Interface && !new.IsNil() {
+		new = new.Elem()
+	}
+	if old.Kind() != reflect.Pointer || new.Kind() != reflect.Pointer || old.Type() != new.Type() ||
+		old.IsNil() || new.IsNil() {
+		return func() {}, true
+	}
+	pair := visitedPair{old: old.Pointer(), new: new.Pointer(), typ: old.Type()}
+	if _, ok := d.visiting[pair]; ok {
+		return nil, false
+	}
+	d.visiting[pair] = struct{}{}
+	return func() {
+		delete(d.visiting, pair)
+	}, true
+}
+
+// diffStructOf returns the struct val holds or points to, if it is compared field by field.
+func diffStructOf(val reflect.Value) (reflect.Value, bool) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return reflect.Value{}, false
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct || hasEqual(val.Type()) {
+		return reflect.Value{}, false
+	}
+	return val, true
+}
+
+// hasEqual reports whether typ has a method Equal(typ) bool, e.g. time.Time.
+func hasEqual(typ reflect.Type) bool {
+	m, ok := typ.MethodByName("Equal")
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == typ &&
+		m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool
+}
+
+func equal(old, new reflect.Value) bool {
+	if !old.IsValid() || !new.IsValid() {
+		return old.IsValid() == new.IsValid()
+	}
+	if old.Type() == new.Type() && hasEqual(old.Type()) {
+		return old.MethodByName("Equal").Call([]reflect.Value{new})[0].Bool()
+	}
+	return reflect.DeepEqual(old.Interface(), new.Interface())
+}
+
+func interfaceOf(val reflect.Value) any {
+	if !val.IsValid() {
+		return nil
+	}
+	return val.Interface()
+}
@@ -0,0 +1,164 @@
+package reflectx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Order struct {
+	ID        int64             `json:"id"`
+	Status    string            `json:"status"`
+	Amount    float64           `json:"amount"`
+	Address   Address           `json:"address"`
+	Contact   *Address          `json:"contact"`
+	Items     []string          `json:"items"`
+	Labels    map[string]string `json:"labels"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Version   int               `json:"version" diff:"-"`
+	note      string
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+	old := Order{
+		ID:        1,
+		Status:    "created",
+		Amount:    10,
+		Address:   Address{City: "Shanghai", Street: "Nanjing Road"},
+		Items:     []string{"a"},
+		Labels:    map[string]string{"k": "v"},
+		UpdatedAt: now,
+		Version:   1,
+		note:      "a",
+	}
+
+	testCases := []struct {
+		name string
+		old  any
+		new  any
+		opts []DiffOption
+		want []Change
+	}{
+		{
+			name: "无变化",
+			old:  old,
+			new: func() Order {
+				o := old
+				// 时间使用 Equal 比较，忽略单调时钟
+				o.UpdatedAt = now.Round(0)
+				o.Version = 2
+				o.note = "b"
+				return o
+			}(),
+		},
+		{
+			name: "字段变化",
+			old:  &old,
+			new: &Order{
+				ID:        1,
+				Status:    "paid",
+				Amount:    10,
+				Address:   Address{City: "Beijing", Street: "Nanjing Road"},
+				Contact:   &Address{City: "Shanghai"},
+				Items:     []string{"a", "b"},
+				Labels:    map[string]string{"k": "v"},
+				UpdatedAt: now.Add(time.Second),
+			},
+			want: []Change{
+				{Path: "Status", Old: "created", New: "paid"},
+				{Path: "Address.City", Old: "Shanghai", New: "Beijing"},
+				{Path: "Contact", Old: (*Address)(nil), New: &Address{City: "Shanghai"}},
+				{Path: "Items", Old: []string{"a"}, New: []string{"a", "b"}},
+				{Path: "UpdatedAt", Old: now, New: now.Add(time.Second)},
+			},
+		},
+		{
+			name: "标签路径和忽略字段",
+			old:  old,
+			new: Order{
+				ID:        2,
+				Status:    "created",
+				Amount:    10,
+				Address:   Address{City: "Beijing", Street: "Wangfujing"},
+				Items:     []string{"a"},
+				Labels:    map[string]string{"k": "v"},
+				UpdatedAt: now.Add(time.Second),
+			},
+			opts: []DiffOption{WithPathTag("json"), WithIgnoreFields("updated_at", "address.street")},
+			want: []Change{
+				{Path: "id", Old: int64(1), New: int64(2)},
+				{Path: "address.city", Old: "Shanghai", New: "Beijing"},
+			},
+		},
+		{
+			name: "嵌套指针",
+			old:  Order{Contact: &Address{City: "Shanghai"}},
+			new:  Order{Contact: &Address{City: "Beijing"}},
+			want: []Change{{Path: "Contact.City", Old: "Shanghai", New: "Beijing"}},
+		},
+		{
+			name: "类型不同",
+			old:  1,
+			new:  "1",
+			want: []Change{{Path: "", Old: 1, New: "1"}},
+		},
+		{
+			name: "nil",
+			old:  nil,
+			new:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Diff(tc.old, tc.new, tc.opts...))
+		})
+	}
+}
+
+type category struct {
+	Name   string
+	Parent *category
+}
+
+func TestDiff_Cycle(t *testing.T) {
+	newCycle := func(name string) *category {
+		c := &category{Name: name}
+		c.Parent = c
+		return c
+	}
+	root := newCycle("root")
+
+	testCases := []struct {
+		name string
+		old  any
+		new  any
+		want []Change
+	}{
+		{
+			name: "引用自身",
+			old:  newCycle("a"),
+			new:  newCycle("b"),
+			want: []Change{{Path: "Name", Old: "a", New: "b"}},
+		},
+		{
+			name: "引用同一个对象",
+			old:  &category{Name: "a", Parent: root},
+			new:  &category{Name: "a", Parent: root},
+		},
+		{
+			name: "引用不同的对象",
+			old:  &category{Name: "a", Parent: root},
+			new:  &category{Name: "a", Parent: newCycle("b")},
+			want: []Change{{Path: "Parent.Name", Old: "root", New: "b"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Diff(tc.old, tc.new))
+		})
+	}
+}
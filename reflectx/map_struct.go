@@ -0,0 +1,195 @@
+package reflectx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+// Unmarshaler is implemented by types decoding themselves from the values in the map,
+// it takes precedence over the other conversions of MapToStruct.
+type Unmarshaler interface {
+	UnmarshalValue(v any) error
+}
+
+var unmarshalerType = reflect.TypeFor[Unmarshaler]()
+
+type decoder struct {
+	tag string
+}
+
+type DecodeOption = option.Option[decoder]
+
+// WithTag sets the tag of the field names, which is "json" by default.
+func WithTag(tag string) DecodeOption {
+	return func(d *decoder) {
+		d.tag = tag
+	}
+}
+
+// MapToStruct populates the struct out points to with m, e.g. a config or the claims of a token.
+// The keys are matched with the names in the tag, or the field names, case-insensitively if there is
+// no exact match, and the fields of embedded structs without a name in the tag are promoted.
+// Unknown keys are ignored, nested maps populate nested structs and maps, and values are converted
+// weakly, e.g. "1" or float64(1) to int, 1 or "true" to bool, "1s" to time.Duration,
+// and strings to types implementing encoding.TextUnmarshaler such as time.Time.
+func MapToStruct(m map[string]any, out any, opts ...DecodeOption) error {
+	d := &decoder{tag: "json"}
+	option.Apply(d, opts...)
+
+	val := reflect.ValueOf(out)
+	if val.Kind() != reflect.Pointer || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("zkit: out 必须是指向结构体的非 nil 指针，实际为 %T", out)
+	}
+	return d.decodeStruct(val.Elem(), m)
+}
+
+// decode converts src and sets it to dst, it populates structs, slices and maps element by element
+// so that their elements are decoded as well, and leaves the other conversions to SetValue.
+func (d *decoder) decode(dst reflect.Value, src any) error {
+	if dst.CanAddr() && dst.Addr().Type().Implements(unmarshalerType) {
+		return dst.Addr().Interface().(Unmarshaler).UnmarshalValue(src)
+	}
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return d.decode(dst.Elem(), src)
+	}
+
+	sv := reflect.ValueOf(src)
+	if !sv.Type().AssignableTo(dst.Type()) {
+		switch dst.Kind() {
+		case reflect.Struct:
+			if m, ok := toStringMap(sv); ok {
+				return d.decodeStruct(dst, m)
+			}
+		case reflect.Slice:
+			return d.decodeSlice(dst, sv)
+		case reflect.Map:
+			return d.decodeMap(dst, sv)
+		}
+	}
+	return SetValue(dst, src)
+}
+
+// decodeStruct populates the struct dst with m.
+func (d *decoder) decodeStruct(dst reflect.Value, m map[string]any) error {
+	for _, field := range StructFields(dst.Type(), d.tag) {
+		if field.Tag.Ignored {
+			continue
+		}
+		fv := dst.FieldByIndex(field.Index)
+		if field.Embedded() {
+			if fv.Kind() == reflect.Pointer {
+				if !field.IsExported() {
+					continue // can't allocate unexported pointers
+				}
+				if fv.IsNil() {
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			if err := d.decodeStruct(fv, m); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Name
+		if name == "" {
+			name = field.Name
+		}
+		src, ok := lookup(m, name)
+		if !ok {
+			continue
+		}
+		if err := d.decode(fv, src); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeSlice(dst reflect.Value, sv reflect.Value) error {
+	switch sv.Kind() {
+	case reflect.Slice, reflect.Array:
+		res := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			if err := d.decode(res.Index(i), sv.Index(i).Interface()); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		dst.Set(res)
+		return nil
+	case reflect.String:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			return SetValue(dst, sv.Interface())
+		}
+	}
+	// a single value becomes a slice of one element
+	res := reflect.MakeSlice(dst.Type(), 1, 1)
+	if err := d.decode(res.Index(0), sv.Interface()); err != nil {
+		return err
+	}
+	dst.Set(res)
+	return nil
+}
+
+func (d *decoder) decodeMap(dst reflect.Value, sv reflect.Value) error {
+	if sv.Kind() != reflect.Map {
+		return mismatch(sv.Interface(), dst.Type())
+	}
+	res := reflect.MakeMapWithSize(dst.Type(), sv.Len())
+	iter := sv.MapRange()
+	for iter.Next() {
+		key := reflect.New(dst.Type().Key()).Elem()
+		if err := d.decode(key, iter.Key().Interface()); err != nil {
+			return err
+		}
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		if err := d.decode(elem, iter.Value().Interface()); err != nil {
+			return fmt.Errorf("%v: %w", iter.Key().Interface(), err)
+		}
+		res.SetMapIndex(key, elem)
+	}
+	dst.Set(res)
+	return nil
+}
+
+// lookup finds the value of key in m, case-insensitively if there is no exact match.
+func lookup(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// toStringMap returns the map sv holds if its keys are strings.
+func toStringMap(sv reflect.Value) (map[string]any, bool) {
+	if m, ok := sv.Interface().(map[string]any); ok {
+		return m, true
+	}
+	if sv.Kind() != reflect.Map || sv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]any, sv.Len())
+	iter := sv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
@@ -0,0 +1,121 @@
+package reflectx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Level int
+
+func (l *Level) UnmarshalValue(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return errors.New("level 必须是字符串")
+	}
+	switch strings.ToLower(s) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	default:
+		return errors.New("未知的 level")
+	}
+	return nil
+}
+
+type Config struct {
+	Base
+	Name     string            `json:"name"`
+	Port     int               `json:"port"`
+	Debug    bool              `json:"debug"`
+	Ratio    float32           `json:"ratio"`
+	Timeout  time.Duration     `json:"timeout"`
+	Level    Level             `json:"level"`
+	Address  *Address          `json:"address"`
+	Hosts    []string          `json:"hosts"`
+	Ports    []uint16          `json:"ports"`
+	Labels   map[string]string `json:"labels"`
+	Ignored  string            `json:"-"`
+	Extra    any               `json:"extra"`
+	MaxConns int
+}
+
+func TestMapToStruct(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	m := map[string]any{
+		"id":         float64(1),
+		"created_at": now.Format(time.RFC3339),
+		"name":       "zkit",
+		"port":       "8080",
+		"debug":      "true",
+		"ratio":      1,
+		"timeout":    "3s",
+		"level":      "info",
+		"address":    map[string]any{"city": "Shanghai"},
+		"hosts":      "localhost",
+		"ports":      []any{float64(80), "443"},
+		"labels":     map[string]any{"env": "prod", "version": 2},
+		"Ignored":    "ignored",
+		"extra":      []any{1, "a"},
+		"maxconns":   100,
+		"unknown":    "unknown",
+	}
+
+	var cfg Config
+	require.NoError(t, MapToStruct(m, &cfg))
+	assert.Equal(t, Config{
+		Base:     Base{ID: 1, CreatedAt: now},
+		Name:     "zkit",
+		Port:     8080,
+		Debug:    true,
+		Ratio:    1,
+		Timeout:  3 * time.Second,
+		Level:    1,
+		Address:  &Address{City: "Shanghai"},
+		Hosts:    []string{"localhost"},
+		Ports:    []uint16{80, 443},
+		Labels:   map[string]string{"env": "prod", "version": "2"},
+		Extra:    []any{1, "a"},
+		MaxConns: 100,
+	}, cfg)
+}
+
+func TestMapToStruct_Error(t *testing.T) {
+	testCases := []struct {
+		name    string
+		m       map[string]any
+		out     any
+		wantErr string
+	}{
+		{name: "非指针", out: Config{}, wantErr: "zkit: out 必须是指向结构体的非 nil 指针，实际为 reflectx.Config"},
+		{name: "非结构体指针", out: new(int), wantErr: "zkit: out 必须是指向结构体的非 nil 指针，实际为 *int"},
+		{name: "小数转整数", m: map[string]any{"port": 1.5}, out: &Config{}, wantErr: "port: zkit: 无法将 float64 类型的 1.5 转换为 int"},
+		{name: "溢出", m: map[string]any{"ports": []any{70000}}, out: &Config{}, wantErr: "ports: [0]: zkit: 70000 超出了 uint16 的范围"},
+		{name: "负数转无符号整数", m: map[string]any{"ports": -1}, out: &Config{}, wantErr: "ports: zkit: -1 超出了 uint16 的范围"},
+		{name: "无效字符串", m: map[string]any{"debug": "yes"}, out: &Config{}, wantErr: `debug: strconv.ParseBool: parsing "yes": invalid syntax`},
+		{name: "类型不匹配", m: map[string]any{"address": "Shanghai"}, out: &Config{}, wantErr: "address: zkit: 无法将 string 类型的 Shanghai 转换为 reflectx.Address"},
+		{name: "Unmarshaler", m: map[string]any{"level": "trace"}, out: &Config{}, wantErr: "level: 未知的 level"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := MapToStruct(tc.m, tc.out)
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestMapToStruct_Tag(t *testing.T) {
+	var claims struct {
+		Subject string `claim:"sub"`
+		Expires int64  `claim:"exp"`
+	}
+	require.NoError(t, MapToStruct(map[string]any{"sub": "user-1", "exp": float64(1700000000)}, &claims, WithTag("claim")))
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, int64(1700000000), claims.Expires)
+}
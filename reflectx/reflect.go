@@ -1,22 +1,82 @@
 package reflectx
 
-import "reflect"
+import (
+	"errors"
+	"reflect"
+)
+
+var ErrNotNillable = errors.New("zkit: 该类型的值不可能为 nil")
 
 // IsNilValue further encapsulates the IsNil method.
 // The IsNil method can be executed if val is of a type map, chan, slice, interface, ptr, and func.
-// Otherwise, return false to avoid a panic when the IsNil method is executed.
-// In particular, if val itself is an illegal value (e.g., nil), you need to pass the IsValid method first to avoid a later panic.
+// Otherwise, return false to avoid a panic when the IsNil method is executed,
+// since values of the other kinds, e.g. int or struct, can never be nil.
+// In particular, if val itself is an illegal value (e.g., nil), it returns true.
 func IsNilValue(val reflect.Value) bool {
+	isNil, _ := IsNilValueStrict(val)
+	return isNil
+}
+
+// IsNilValueStrict is like IsNilValue, but returns ErrNotNillable for the kinds which can never be nil,
+// so that callers can tell a non-nil value from a value that is not nillable at all.
+func IsNilValueStrict(val reflect.Value) (bool, error) {
 	// Determine if reflect.Value itself is an illegal value, e.g., nil, to avoid a subsequent panic when fetching val.Type().
 	if !val.IsValid() {
-		return true
+		return true, nil
 	}
 	// Determine if the IsNil method can be executed based on the type.
-	switch val.Type().Kind() {
+	switch val.Kind() {
 	case reflect.Map, reflect.Chan, reflect.Slice, reflect.Interface, reflect.Ptr, reflect.Func, reflect.UnsafePointer:
+		return val.IsNil(), nil
+	default:
+		return false, ErrNotNillable
+	}
+}
+
+// isZeroer is implemented by types defining their own zero values, e.g. time.Time.
+type isZeroer interface {
+	IsZero() bool
+}
+
+var isZeroerType = reflect.TypeFor[isZeroer]()
+
+// IsZero reports whether v is empty, which is broader than reflect.Value.IsZero:
+//   - nil, nil pointers and nil interfaces are zero, non-nil pointers are not
+//   - slices, maps, strings and channels are zero if they are empty, not only nil
+//   - types implementing IsZero() bool, e.g. time.Time, report by themselves
+//   - the other values are zero if they equal the zero values of their types
+func IsZero(v any) bool {
+	return isZeroValue(reflect.ValueOf(v))
+}
+
+func isZeroValue(val reflect.Value) bool {
+	if !val.IsValid() {
+		return true
+	}
+	if val.Type().Implements(isZeroerType) {
+		if val.Kind() == reflect.Pointer && val.IsNil() {
+			return true
+		}
+		return val.Interface().(isZeroer).IsZero()
+	}
+	switch val.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Chan:
+		return val.Len() == 0
+	case reflect.Interface:
 		return val.IsNil()
 	default:
-		panic("unhandled default case")
+		if val.CanAddr() && val.Addr().Type().Implements(isZeroerType) {
+			return val.Addr().Interface().(isZeroer).IsZero()
+		}
+		return val.IsZero()
+	}
+}
+
+// ZeroOf returns the zero value of typ, e.g. nil for pointers and 0 for int.
+// It returns nil if typ is nil.
+func ZeroOf(typ reflect.Type) any {
+	if typ == nil {
+		return nil
 	}
-	return false
+	return reflect.Zero(typ).Interface()
 }
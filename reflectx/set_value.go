@@ -0,0 +1,301 @@
+package reflectx
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+// timeLayouts are the layouts tried in order when parsing strings into time.Time
+var timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// SetValue converts src to the type of dst and sets it, dst must be settable.
+// It converts between compatible kinds, e.g. strings, numbers and bools to each other,
+// float64(1) to int but not 1.5, and reports an error if a number overflows.
+// Pointers are allocated or dereferenced as needed, and nil sets dst to its zero value.
+// Strings are parsed into time.Duration by time.ParseDuration if they are not integers,
+// into time.Time in the layouts of RFC 3339, time.DateTime and time.DateOnly, and into
+// the other types implementing encoding.TextUnmarshaler by UnmarshalText.
+// Numbers are converted into time.Time as Unix seconds.
+func SetValue(dst reflect.Value, src any) error {
+	if !dst.CanSet() {
+		return fmt.Errorf("zkit: 无法设置 %s 类型的值", dst.Type())
+	}
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Pointer && !sv.Type().AssignableTo(dst.Type()) {
+		if sv.IsNil() {
+			src, sv = nil, reflect.Value{}
+			break
+		}
+		sv = sv.Elem()
+		src = sv.Interface()
+	}
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer && !sv.Type().AssignableTo(dst.Type()) {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := SetValue(ptr.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	if dst.Type() == timeType {
+		return setTime(dst, sv)
+	}
+	if sv.Kind() == reflect.String && dst.Addr().Type().Implements(textUnmarshalerType) {
+		return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(sv.String()))
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		return setString(dst, sv)
+	case reflect.Bool:
+		return setBool(dst, sv)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setInt(dst, sv)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return setUint(dst, sv)
+	case reflect.Float32, reflect.Float64:
+		return setFloat(dst, sv)
+	case reflect.Slice:
+		if sv.Kind() == reflect.String && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(sv.String()))
+			return nil
+		}
+	}
+	if sv.Type().ConvertibleTo(dst.Type()) && !shortSlice(sv, dst.Type()) {
+		dst.Set(sv.Convert(dst.Type()))
+		return nil
+	}
+	return mismatch(src, dst.Type())
+}
+
+// shortSlice reports whether sv is a slice shorter than the array typ is or points to,
+// which panics when it is converted.
+func shortSlice(sv reflect.Value, typ reflect.Type) bool {
+	if sv.Kind() != reflect.Slice {
+		return false
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Array && sv.Len() < typ.Len()
+}
+
+func setTime(dst reflect.Value, sv reflect.Value) error {
+	switch sv.Kind() {
+	case reflect.String:
+		var firstErr error
+		for _, layout := range timeLayouts {
+			t, err := time.Parse(layout, sv.String())
+			if err == nil {
+				dst.Set(reflect.ValueOf(t))
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dst.Set(reflect.ValueOf(time.Unix(sv.Int(), 0)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if sv.Uint() > math.MaxInt64 {
+			return overflow(sv.Interface(), dst.Type())
+		}
+		dst.Set(reflect.ValueOf(time.Unix(int64(sv.Uint()), 0)))
+	case reflect.Float32, reflect.Float64:
+		sec, frac := math.Modf(sv.Float())
+		dst.Set(reflect.ValueOf(time.Unix(int64(sec), int64(frac*1e9))))
+	default:
+		return mismatch(sv.Interface(), dst.Type())
+	}
+	return nil
+}
+
+func setString(dst reflect.Value, sv reflect.Value) error {
+	switch sv.Kind() {
+	case reflect.String:
+		dst.SetString(sv.String())
+	case reflect.Bool:
+		dst.SetString(strconv.FormatBool(sv.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dst.SetString(strconv.FormatInt(sv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		dst.SetString(strconv.FormatUint(sv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		dst.SetString(strconv.FormatFloat(sv.Float(), 'f', -1, sv.Type().Bits()))
+	case reflect.Slice:
+		if sv.Type().Elem().Kind() != reflect.Uint8 {
+			return mismatch(sv.Interface(), dst.Type())
+		}
+		dst.SetString(string(sv.Bytes()))
+	default:
+		if m, ok := sv.Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			if err != nil {
+				return err
+			}
+			dst.SetString(string(text))
+			return nil
+		}
+		return mismatch(sv.Interface(), dst.Type())
+	}
+	return nil
+}
+
+func setBool(dst reflect.Value, sv reflect.Value) error {
+	switch sv.Kind() {
+	case reflect.Bool:
+		dst.SetBool(sv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dst.SetBool(sv.Int() != 0)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		dst.SetBool(sv.Uint() != 0)
+	case reflect.Float32, reflect.Float64:
+		dst.SetBool(sv.Float() != 0)
+	case reflect.String:
+		if sv.String() == "" {
+			dst.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(sv.String())
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	default:
+		return mismatch(sv.Interface(), dst.Type())
+	}
+	return nil
+}
+
+func setInt(dst reflect.Value, sv reflect.Value) error {
+	var i int64
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = sv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if sv.Uint() > math.MaxInt64 {
+			return overflow(sv.Interface(), dst.Type())
+		}
+		i = int64(sv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := sv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return mismatch(sv.Interface(), dst.Type())
+		}
+		i = int64(f)
+	case reflect.Bool:
+		if sv.Bool() {
+			i = 1
+		}
+	case reflect.String:
+		var err error
+		if i, err = strconv.ParseInt(sv.String(), 10, 64); err != nil {
+			if dst.Type() != durationType {
+				return err
+			}
+			// durations accept both nanoseconds and strings like "1s"
+			dur, derr := time.ParseDuration(sv.String())
+			if derr != nil {
+				return derr
+			}
+			i = int64(dur)
+		}
+	default:
+		return mismatch(sv.Interface(), dst.Type())
+	}
+	if dst.OverflowInt(i) {
+		return overflow(sv.Interface(), dst.Type())
+	}
+	dst.SetInt(i)
+	return nil
+}
+
+func setUint(dst reflect.Value, sv reflect.Value) error {
+	var u uint64
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if sv.Int() < 0 {
+			return overflow(sv.Interface(), dst.Type())
+		}
+		u = uint64(sv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u = sv.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := sv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return mismatch(sv.Interface(), dst.Type())
+		}
+		u = uint64(f)
+	case reflect.Bool:
+		if sv.Bool() {
+			u = 1
+		}
+	case reflect.String:
+		var err error
+		if u, err = strconv.ParseUint(sv.String(), 10, 64); err != nil {
+			return err
+		}
+	default:
+		return mismatch(sv.Interface(), dst.Type())
+	}
+	if dst.OverflowUint(u) {
+		return overflow(sv.Interface(), dst.Type())
+	}
+	dst.SetUint(u)
+	return nil
+}
+
+func setFloat(dst reflect.Value, sv reflect.Value) error {
+	var f float64
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(sv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(sv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = sv.Float()
+	case reflect.Bool:
+		if sv.Bool() {
+			f = 1
+		}
+	case reflect.String:
+		var err error
+		if f, err = strconv.ParseFloat(sv.String(), 64); err != nil {
+			return err
+		}
+	default:
+		return mismatch(sv.Interface(), dst.Type())
+	}
+	if dst.OverflowFloat(f) {
+		return overflow(sv.Interface(), dst.Type())
+	}
+	dst.SetFloat(f)
+	return nil
+}
+
+func mismatch(src any, typ reflect.Type) error {
+	return fmt.Errorf("zkit: 无法将 %T 类型的 %v 转换为 %s", src, src, typ)
+}
+
+func overflow(src any, typ reflect.Type) error {
+	return fmt.Errorf("zkit: %v 超出了 %s 的范围", src, typ)
+}
@@ -0,0 +1,74 @@
+package reflectx
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetValue(t *testing.T) {
+	n := 8
+	var nilPtr *int
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		dst     any // 指向目标的指针
+		src     any
+		want    any
+		wantErr string
+	}{
+		{name: "相同类型", dst: new(int), src: 1, want: 1},
+		{name: "字符串转整数", dst: new(int64), src: "-42", want: int64(-42)},
+		{name: "整数转字符串", dst: new(string), src: 42, want: "42"},
+		{name: "浮点数转字符串", dst: new(string), src: 1.5, want: "1.5"},
+		{name: "布尔转字符串", dst: new(string), src: true, want: "true"},
+		{name: "字符串转布尔", dst: new(bool), src: "1", want: true},
+		{name: "空字符串转布尔", dst: new(bool), src: "", want: false},
+		{name: "整数转布尔", dst: new(bool), src: 2, want: true},
+		{name: "布尔转整数", dst: new(uint8), src: true, want: uint8(1)},
+		{name: "整数型浮点数转整数", dst: new(int), src: float64(3), want: 3},
+		{name: "字符串转浮点数", dst: new(float32), src: "1.25", want: float32(1.25)},
+		{name: "字符串转 []byte", dst: new([]byte), src: "abc", want: []byte("abc")},
+		{name: "[]byte 转字符串", dst: new(string), src: []byte("abc"), want: "abc"},
+		{name: "时长字符串", dst: new(time.Duration), src: "1m30s", want: 90 * time.Second},
+		{name: "纳秒时长", dst: new(time.Duration), src: "1000", want: time.Microsecond},
+		{name: "RFC3339 时间", dst: new(time.Time), src: "2025-01-02T03:04:05Z", want: ts},
+		{name: "DateTime 时间", dst: new(time.Time), src: "2025-01-02 03:04:05", want: ts},
+		{name: "DateOnly 时间", dst: new(time.Time), src: "2025-01-02", want: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "Unix 秒", dst: new(time.Time), src: ts.Unix(), want: time.Unix(ts.Unix(), 0)},
+		{name: "时间转字符串", dst: new(string), src: ts, want: "2025-01-02T03:04:05Z"},
+		{name: "TextUnmarshaler", dst: new(net.IP), src: "127.0.0.1", want: net.ParseIP("127.0.0.1")},
+		{name: "分配指针", dst: new(*int), src: "8", want: &n},
+		{name: "解引用指针", dst: new(string), src: &n, want: "8"},
+		{name: "nil 指针", dst: &n, src: nilPtr, want: 0},
+		{name: "nil", dst: new(*int), src: nil, want: (*int)(nil)},
+		{name: "可转换的类型", dst: new(Level), src: 1, want: Level(1)},
+		{name: "切片转数组", dst: new([2]int), src: []int{1, 2, 3}, want: [2]int{1, 2}},
+		{name: "切片短于数组", dst: new([2]int), src: []int{1}, wantErr: "zkit: 无法将 []int 类型的 [1] 转换为 [2]int"},
+		{name: "小数转整数", dst: new(int), src: 1.5, wantErr: "zkit: 无法将 float64 类型的 1.5 转换为 int"},
+		{name: "溢出", dst: new(int8), src: "200", wantErr: "zkit: 200 超出了 int8 的范围"},
+		{name: "无效的整数", dst: new(int), src: "abc", wantErr: `strconv.ParseInt: parsing "abc": invalid syntax`},
+		{name: "无效的时间", dst: new(time.Time), src: "abc", wantErr: `parsing time "abc" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "abc" as "2006"`},
+		{name: "类型不匹配", dst: new(int), src: []int{1}, wantErr: "zkit: 无法将 []int 类型的 [1] 转换为 int"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := reflect.ValueOf(tc.dst).Elem()
+			err := SetValue(dst, tc.src)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, dst.Interface())
+		})
+	}
+
+	err := SetValue(reflect.ValueOf(1), 2)
+	assert.EqualError(t, err, "zkit: 无法设置 int 类型的值")
+}
@@ -0,0 +1,124 @@
+package reflectx
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+type structMapper struct {
+	tag       string
+	separator string
+}
+
+type StructMapOption = option.Option[structMapper]
+
+// WithFlatten flattens the fields of nested structs into the top level map,
+// their keys are joined with the keys of the parents by sep, e.g. "address.city" with ".".
+func WithFlatten(sep string) StructMapOption {
+	return func(m *structMapper) {
+		m.separator = sep
+	}
+}
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// StructToMap converts the exported fields of the struct v, or a pointer to it, into a map.
+// The keys are the names in the tag, e.g. "json", or the field names if the tag or its name is empty.
+// Fields tagged "-" are skipped, and so are the fields with the omitempty option if IsZero reports true.
+// The fields of embedded structs without a name in the tag are promoted like encoding/json does.
+// Nested structs are converted into nested maps, or flattened with WithFlatten,
+// except the ones marshaling themselves such as time.Time, which are kept as they are.
+// It returns nil if v is not a struct.
+func StructToMap(v any, tag string, opts ...StructMapOption) map[string]any {
+	m := &structMapper{tag: tag}
+	option.Apply(m, opts...)
+
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+	res := make(map[string]any, val.NumField())
+	m.convert(val, res, "")
+	return res
+}
+
+// convert puts the fields of the struct val into res with keys prefixed by prefix.
+func (m *structMapper) convert(val reflect.Value, res map[string]any, prefix string) {
+	// promoted holds the fields of embedded structs, which are shadowed by the direct fields
+	promoted := make(map[string]any)
+	for _, field := range StructFields(val.Type(), m.tag) {
+		if field.Tag.Ignored {
+			continue
+		}
+		fv := val.FieldByIndex(field.Index)
+		if field.Tag.Has("omitempty") && isZeroValue(fv) {
+			continue
+		}
+
+		if field.Embedded() {
+			if embedded, ok := structOf(fv); ok {
+				m.convert(embedded, promoted, prefix)
+				continue
+			}
+			// skips nil pointers to embedded structs like encoding/json
+			if fv.Kind() == reflect.Pointer {
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Name
+		if name == "" {
+			name = field.Name
+		}
+
+		key := prefix + name
+		nested, ok := structOf(fv)
+		switch {
+		case !ok:
+			res[key] = fv.Interface()
+		case m.separator != "":
+			m.convert(nested, res, key+m.separator)
+		default:
+			sub := make(map[string]any, nested.NumField())
+			m.convert(nested, sub, "")
+			res[key] = sub
+		}
+	}
+	for key, val := range promoted {
+		if _, ok := res[key]; !ok {
+			res[key] = val
+		}
+	}
+}
+
+// structOf returns the struct val holds or points to, if it is to be converted into a map.
+func structOf(val reflect.Value) (reflect.Value, bool) {
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return reflect.Value{}, false
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	ptr := reflect.PointerTo(val.Type())
+	if ptr.Implements(textMarshalerType) || ptr.Implements(jsonMarshalerType) {
+		return reflect.Value{}, false
+	}
+	return val, true
+}
@@ -0,0 +1,122 @@
+package reflectx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Base struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Address struct {
+	City   string `json:"city"`
+	Street string `json:"street,omitempty"`
+}
+
+type User struct {
+	Base
+	Name     string   `json:"name"`
+	Nickname string   `json:"nickname,omitempty"`
+	Password string   `json:"-"`
+	Address  Address  `json:"address"`
+	Company  *Address `json:"company,omitempty"`
+	Tags     []string `json:"tags"`
+	Age      int
+	password string
+}
+
+func TestStructToMap(t *testing.T) {
+	now := time.Now()
+	user := User{
+		Base:     Base{ID: 1, CreatedAt: now},
+		Name:     "Tom",
+		Password: "123456",
+		Address:  Address{City: "Shanghai"},
+		Tags:     []string{"admin"},
+		Age:      18,
+		password: "123456",
+	}
+
+	testCases := []struct {
+		name string
+		val  any
+		tag  string
+		opts []StructMapOption
+		want map[string]any
+	}{
+		{
+			name: "json 标签",
+			val:  user,
+			tag:  "json",
+			want: map[string]any{
+				"id":         int64(1),
+				"created_at": now,
+				"name":       "Tom",
+				"address":    map[string]any{"city": "Shanghai"},
+				"tags":       []string{"admin"},
+				"Age":        18,
+			},
+		},
+		{
+			name: "指针和展开",
+			val: &User{
+				Name:    "Tom",
+				Address: Address{City: "Shanghai", Street: "Nanjing Road"},
+				Company: &Address{City: "Beijing"},
+			},
+			tag:  "json",
+			opts: []StructMapOption{WithFlatten(".")},
+			want: map[string]any{
+				"id":             int64(0),
+				"created_at":     time.Time{},
+				"name":           "Tom",
+				"address.city":   "Shanghai",
+				"address.street": "Nanjing Road",
+				"company.city":   "Beijing",
+				"tags":           []string(nil),
+				"Age":            0,
+			},
+		},
+		{
+			name: "无标签",
+			val:  Address{City: "Shanghai"},
+			want: map[string]any{"City": "Shanghai", "Street": ""},
+		},
+		{
+			name: "字段覆盖嵌入结构体的字段",
+			val: struct {
+				Base
+				ID string `json:"id"`
+			}{Base: Base{ID: 1}, ID: "a"},
+			tag:  "json",
+			want: map[string]any{"id": "a", "created_at": time.Time{}},
+		},
+		{
+			name: "嵌入结构体指针为 nil",
+			val: struct {
+				*Base
+				Name string `json:"name"`
+			}{Name: "Tom"},
+			tag:  "json",
+			want: map[string]any{"name": "Tom"},
+		},
+		{
+			name: "非结构体",
+			val:  map[string]any{"a": 1},
+		},
+		{
+			name: "nil 指针",
+			val:  (*User)(nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, StructToMap(tc.val, tc.tag, tc.opts...))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package reflectx
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Tag is a parsed struct tag value in the form of "name,opt1,opt2=v", e.g. `json:"id,omitempty"`.
+type Tag struct {
+	Name string
+	// Options holds the options after the name, the value of an option without "=" is empty
+	Options map[string]string
+	// Ignored reports whether the tag is "-", which means the field is skipped
+	Ignored bool
+}
+
+// ParseTag parses a struct tag value like "name,opt1,opt2=v".
+func ParseTag(tag string) Tag {
+	if tag == "-" {
+		return Tag{Ignored: true}
+	}
+	name, opts, found := strings.Cut(tag, ",")
+	t := Tag{Name: name}
+	if !found {
+		return t
+	}
+	t.Options = make(map[string]string)
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "" {
+			continue
+		}
+		key, val, _ := strings.Cut(opt, "=")
+		t.Options[key] = val
+	}
+	return t
+}
+
+// Has reports whether the tag has the option opt.
+func (t Tag) Has(opt string) bool {
+	_, ok := t.Options[opt]
+	return ok
+}
+
+// Lookup returns the value of the option opt and whether the tag has it.
+func (t Tag) Lookup(opt string) (string, bool) {
+	val, ok := t.Options[opt]
+	return val, ok
+}
+
+// Field is a field of a struct with its tag parsed.
+type Field struct {
+	reflect.StructField
+	Tag Tag
+}
+
+// Embedded reports whether the field is an embedded struct, or a pointer to it, without a name in the tag,
+// whose fields are promoted like encoding/json does.
+func (f Field) Embedded() bool {
+	if !f.Anonymous || f.Tag.Name != "" || f.Tag.Ignored {
+		return false
+	}
+	typ := f.Type
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+type fieldsKey struct {
+	typ reflect.Type
+	key string
+}
+
+var fieldsCache sync.Map // fieldsKey -> []Field
+
+// StructFields returns the exported and the embedded fields of the struct type typ with the tag of key parsed,
+// the result is cached per type and key and must not be modified.
+func StructFields(typ reflect.Type, key string) []Field {
+	cacheKey := fieldsKey{typ: typ, key: key}
+	if fields, ok := fieldsCache.Load(cacheKey); ok {
+		return fields.([]Field)
+	}
+
+	fields := make([]Field, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		var tag Tag
+		if key != "" {
+			tag = ParseTag(field.Tag.Get(key))
+		}
+		fields = append(fields, Field{StructField: field, Tag: tag})
+	}
+	actual, _ := fieldsCache.LoadOrStore(cacheKey, fields)
+	return actual.([]Field)
+}
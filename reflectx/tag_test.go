@@ -0,0 +1,59 @@
+package reflectx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	testCases := []struct {
+		name string
+		tag  string
+		want Tag
+	}{
+		{name: "空", tag: "", want: Tag{}},
+		{name: "名称", tag: "id", want: Tag{Name: "id"}},
+		{name: "忽略", tag: "-", want: Tag{Ignored: true}},
+		{name: "名称为 -", tag: "-,", want: Tag{Name: "-", Options: map[string]string{}}},
+		{name: "选项", tag: "id,omitempty", want: Tag{Name: "id", Options: map[string]string{"omitempty": ""}}},
+		{name: "无名称", tag: ",omitempty", want: Tag{Options: map[string]string{"omitempty": ""}}},
+		{name: "带值的选项", tag: "phone,keep=3,,mask=*", want: Tag{Name: "phone", Options: map[string]string{"keep": "3", "mask": "*"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ParseTag(tc.tag))
+		})
+	}
+
+	tag := ParseTag("phone,keep=3,omitempty")
+	assert.True(t, tag.Has("omitempty"))
+	assert.True(t, tag.Has("keep"))
+	assert.False(t, tag.Has("mask"))
+	val, ok := tag.Lookup("keep")
+	assert.True(t, ok)
+	assert.Equal(t, "3", val)
+	_, ok = tag.Lookup("mask")
+	assert.False(t, ok)
+}
+
+func TestStructFields(t *testing.T) {
+	fields := StructFields(reflect.TypeFor[User](), "json")
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name+":"+f.Tag.Name)
+	}
+	// 未导出的字段被忽略
+	assert.Equal(t, []string{"Base:", "Name:name", "Nickname:nickname", "Password:", "Address:address", "Company:company", "Tags:tags", "Age:"}, names)
+	assert.True(t, fields[0].Embedded())
+	assert.False(t, fields[1].Embedded())
+	assert.True(t, fields[2].Tag.Has("omitempty"))
+	assert.True(t, fields[3].Tag.Ignored)
+
+	// 缓存
+	again := StructFields(reflect.TypeFor[User](), "json")
+	assert.Same(t, &fields[0], &again[0])
+	assert.Equal(t, "", StructFields(reflect.TypeFor[User](), "")[1].Tag.Name)
+}
@@ -0,0 +1,83 @@
+package reflectx
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ptrZeroer struct {
+	val int
+}
+
+func (p *ptrZeroer) IsZero() bool {
+	return p.val < 0
+}
+
+func TestIsZero(t *testing.T) {
+	var (
+		nilPtr   *int
+		nilTime  *time.Time
+		n        = 0
+		nilIface any
+	)
+	testCases := []struct {
+		name string
+		val  any
+		want bool
+	}{
+		{name: "nil", val: nil, want: true},
+		{name: "int 零值", val: 0, want: true},
+		{name: "int 非零值", val: 1, want: false},
+		{name: "空字符串", val: "", want: true},
+		{name: "nil 指针", val: nilPtr, want: true},
+		{name: "指向零值的指针", val: &n, want: false},
+		{name: "空切片", val: []int{}, want: true},
+		{name: "非空切片", val: []int{0}, want: false},
+		{name: "空 map", val: map[string]int{}, want: true},
+		{name: "time.Time 零值", val: time.Time{}, want: true},
+		{name: "time.Time 非零值", val: time.Now(), want: false},
+		{name: "nil *time.Time", val: nilTime, want: true},
+		{name: "*time.Time 零值", val: &time.Time{}, want: true},
+		{name: "结构体零值", val: struct{ A int }{}, want: true},
+		{name: "结构体非零值", val: struct{ A int }{A: 1}, want: false},
+		{name: "指针接收者的 IsZero", val: &ptrZeroer{val: -1}, want: true},
+		{name: "nil 接口", val: nilIface, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsZero(tc.val))
+		})
+	}
+
+	// 可寻址的值使用指针接收者的 IsZero
+	v := struct{ P ptrZeroer }{P: ptrZeroer{val: -1}}
+	assert.True(t, isZeroValue(reflect.ValueOf(&v).Elem().Field(0)))
+	assert.False(t, isZeroValue(reflect.ValueOf(v).Field(0)))
+}
+
+func TestZeroOf(t *testing.T) {
+	assert.Nil(t, ZeroOf(nil))
+	assert.Equal(t, 0, ZeroOf(reflect.TypeFor[int]()))
+	assert.Equal(t, "", ZeroOf(reflect.TypeFor[string]()))
+	assert.Equal(t, (*int)(nil), ZeroOf(reflect.TypeFor[*int]()))
+	assert.Equal(t, time.Time{}, ZeroOf(reflect.TypeFor[time.Time]()))
+}
+
+func TestIsNilValueStrict(t *testing.T) {
+	var nilPtr *int
+	isNil, err := IsNilValueStrict(reflect.ValueOf(nilPtr))
+	assert.NoError(t, err)
+	assert.True(t, isNil)
+
+	isNil, err = IsNilValueStrict(reflect.ValueOf(nil))
+	assert.NoError(t, err)
+	assert.True(t, isNil)
+
+	isNil, err = IsNilValueStrict(reflect.ValueOf(1))
+	assert.Equal(t, ErrNotNillable, err)
+	assert.False(t, isNil)
+}
@@ -0,0 +1,107 @@
+package stringx
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+// Builder builds strings like strings.Builder, but keeps its buffer on Reset so that it can be
+// reused through a BuilderPool. String copies the buffer for the same reason.
+type Builder struct {
+	buf []byte
+}
+
+func (b *Builder) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+func (b *Builder) WriteString(s string) (int, error) {
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+func (b *Builder) WriteByte(c byte) error {
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+func (b *Builder) WriteRune(r rune) (int, error) {
+	n := len(b.buf)
+	b.buf = utf8.AppendRune(b.buf, r)
+	return len(b.buf) - n, nil
+}
+
+// Grow grows the capacity of the buffer to hold at least n more bytes.
+func (b *Builder) Grow(n int) {
+	if n > cap(b.buf)-len(b.buf) {
+		buf := make([]byte, len(b.buf), 2*cap(b.buf)+n)
+		copy(buf, b.buf)
+		b.buf = buf
+	}
+}
+
+func (b *Builder) Len() int {
+	return len(b.buf)
+}
+
+// String returns a copy of the accumulated string.
+func (b *Builder) String() string {
+	return string(b.buf)
+}
+
+// Reset empties the builder and keeps the buffer.
+func (b *Builder) Reset() {
+	b.buf = b.buf[:0]
+}
+
+// BuilderPool pools Builders, the zero value is ready to use.
+type BuilderPool struct {
+	pool sync.Pool
+	// MaxCap is the maximum capacity of the builders put back, larger ones are dropped so that
+	// a single huge string does not pin memory, it defaults to 64KB.
+	MaxCap int
+}
+
+// Get returns an empty Builder, which should be put back by Put.
+func (p *BuilderPool) Get() *Builder {
+	if b, ok := p.pool.Get().(*Builder); ok {
+		return b
+	}
+	return &Builder{buf: make([]byte, 0, 256)}
+}
+
+// Put resets b and puts it back to the pool, b must not be used after that.
+func (p *BuilderPool) Put(b *Builder) {
+	maxCap := p.MaxCap
+	if maxCap <= 0 {
+		maxCap = 64 << 10
+	}
+	if cap(b.buf) > maxCap {
+		return
+	}
+	b.Reset()
+	p.pool.Put(b)
+}
+
+var builderPool BuilderPool
+
+// JoinFunc formats items with f and joins them with sep using a pooled Builder,
+// e.g. JoinFunc(ids, ",", strconv.Itoa) for an SQL IN list.
+func JoinFunc[T any](items []T, sep string, f func(T) string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return f(items[0])
+	}
+	b := builderPool.Get()
+	defer builderPool.Put(b)
+	for i, item := range items {
+		if i > 0 {
+			_, _ = b.WriteString(sep)
+		}
+		_, _ = b.WriteString(f(item))
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package stringx
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	var p BuilderPool
+	b := p.Get()
+	_, _ = b.WriteString("hello")
+	_ = b.WriteByte(' ')
+	_, _ = b.WriteRune('世')
+	_, _ = b.Write([]byte("界"))
+	assert.Equal(t, 12, b.Len())
+	s := b.String()
+	assert.Equal(t, "hello 世界", s)
+
+	// the string does not share the buffer reused after Put
+	p.Put(b)
+	b = p.Get()
+	assert.Equal(t, 0, b.Len())
+	_, _ = b.WriteString("overwritten")
+	assert.Equal(t, "hello 世界", s)
+
+	b.Grow(1024)
+	assert.GreaterOrEqual(t, cap(b.buf)-b.Len(), 1024)
+	assert.Equal(t, "overwritten", b.String())
+
+	// large builders are dropped
+	p = BuilderPool{MaxCap: 16}
+	b = p.Get()
+	b.Grow(32)
+	p.Put(b)
+	assert.NotSame(t, b, p.Get())
+}
+
+func TestJoinFunc(t *testing.T) {
+	testCases := []struct {
+		name  string
+		items []int
+		want  string
+	}{
+		{name: "empty", want: ""},
+		{name: "single", items: []int{1}, want: "1"},
+		{name: "multiple", items: []int{1, 2, 3}, want: "1,2,3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, JoinFunc(tc.items, ",", strconv.Itoa))
+		})
+	}
+}
+
+func BenchmarkJoinFunc(b *testing.B) {
+	ids := make([]int, 100)
+	for i := range ids {
+		ids[i] = i
+	}
+	b.Run("JoinFunc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			JoinFunc(ids, ",", strconv.Itoa)
+		}
+	})
+	b.Run("strings.Join", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			strs := make([]string, len(ids))
+			for j, id := range ids {
+				strs[j] = strconv.Itoa(id)
+			}
+			strings.Join(strs, ",")
+		}
+	})
+}
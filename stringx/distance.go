@@ -0,0 +1,58 @@
+package stringx
+
+// Distance returns the Levenshtein distance between a and b, which is the minimum number of
+// runes inserted, deleted or substituted to change a into b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	// only two rows of the matrix are kept, whose length is the shorter one
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Similarity returns the similarity between a and b in [0, 1] based on Distance,
+// 1 means they are equal and 0 means they have nothing in common.
+func Similarity(a, b string) float64 {
+	n := max(len([]rune(a)), len([]rune(b)))
+	if n == 0 {
+		return 1
+	}
+	return 1 - float64(Distance(a, b))/float64(n)
+}
+
+// ClosestMatch returns the candidate most similar to target and its similarity,
+// the former one wins if several candidates are equally similar.
+// It returns false if candidates is empty, callers usually ignore matches below a threshold,
+// e.g. to suggest "did you mean" only if the similarity is at least 0.6.
+func ClosestMatch(target string, candidates []string) (string, float64, bool) {
+	var (
+		match string
+		best  = -1.0
+	)
+	for _, c := range candidates {
+		if s := Similarity(target, c); s > best {
+			match, best = c, s
+		}
+	}
+	if best < 0 {
+		return "", 0, false
+	}
+	return match, best, true
+}
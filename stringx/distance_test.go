@@ -0,0 +1,47 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		name           string
+		a, b           string
+		wantDistance   int
+		wantSimilarity float64
+	}{
+		{name: "equal", a: "hello", b: "hello", wantDistance: 0, wantSimilarity: 1},
+		{name: "empty", a: "", b: "", wantDistance: 0, wantSimilarity: 1},
+		{name: "one empty", a: "abc", b: "", wantDistance: 3, wantSimilarity: 0},
+		{name: "kitten", a: "kitten", b: "sitting", wantDistance: 3, wantSimilarity: 1 - 3.0/7},
+		{name: "swapped", a: "sitting", b: "kitten", wantDistance: 3, wantSimilarity: 1 - 3.0/7},
+		{name: "unicode", a: "你好世界", b: "你好", wantDistance: 2, wantSimilarity: 0.5},
+		{name: "different", a: "abc", b: "xyz", wantDistance: 3, wantSimilarity: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantDistance, Distance(tc.a, tc.b))
+			assert.InDelta(t, tc.wantSimilarity, Similarity(tc.a, tc.b), 1e-9)
+		})
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"timeout", "retries", "base_url", "time"}
+
+	match, similarity, ok := ClosestMatch("timout", candidates)
+	assert.True(t, ok)
+	assert.Equal(t, "timeout", match)
+	assert.InDelta(t, 1-1.0/7, similarity, 1e-9)
+
+	match, _, ok = ClosestMatch("base-url", candidates)
+	assert.True(t, ok)
+	assert.Equal(t, "base_url", match)
+
+	_, _, ok = ClosestMatch("timeout", nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,41 @@
+package stringx
+
+import "strings"
+
+// Mask replaces the runes of s except the first keepPrefix and the last keepSuffix ones with maskRune,
+// s is masked entirely if it is not longer than keepPrefix + keepSuffix, so that short values are not leaked.
+func Mask(s string, keepPrefix, keepSuffix int, maskRune rune) string {
+	runes := []rune(s)
+	keepPrefix, keepSuffix = max(keepPrefix, 0), max(keepSuffix, 0)
+	if len(runes) <= keepPrefix+keepSuffix {
+		keepPrefix, keepSuffix = 0, 0
+	}
+	for i := keepPrefix; i < len(runes)-keepSuffix; i++ {
+		runes[i] = maskRune
+	}
+	return string(runes)
+}
+
+// MaskPhone masks the middle of a phone number, e.g. 131****7078
+func MaskPhone(phone string) string {
+	return Mask(phone, 3, 4, '*')
+}
+
+// MaskEmail masks the local part of an email except its first rune, e.g. z****@example.com
+func MaskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return Mask(email, 1, 0, '*')
+	}
+	return Mask(email[:at], 1, 0, '*') + email[at:]
+}
+
+// MaskIDCard masks an ID card number except the first 3 and the last 4 runes, e.g. 110***********1234
+func MaskIDCard(id string) string {
+	return Mask(id, 3, 4, '*')
+}
+
+// MaskBankCard masks a bank card number except the first 4 and the last 4 runes, e.g. 6222********1234
+func MaskBankCard(card string) string {
+	return Mask(card, 4, 4, '*')
+}
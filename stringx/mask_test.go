@@ -0,0 +1,34 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	testCases := []struct {
+		name string
+		mask func(string) string
+		val  string
+		want string
+	}{
+		{name: "phone", mask: MaskPhone, val: "13117127078", want: "131****7078"},
+		{name: "short phone", mask: MaskPhone, val: "1234567", want: "*******"},
+		{name: "empty phone", mask: MaskPhone, val: "", want: ""},
+		{name: "email", mask: MaskEmail, val: "zkit@example.com", want: "z***@example.com"},
+		{name: "single rune email", mask: MaskEmail, val: "z@example.com", want: "*@example.com"},
+		{name: "not email", mask: MaskEmail, val: "zkit", want: "z***"},
+		{name: "id card", mask: MaskIDCard, val: "110101199003071234", want: "110***********1234"},
+		{name: "bank card", mask: MaskBankCard, val: "6222021234567891234", want: "6222***********1234"},
+		{name: "unicode", mask: func(s string) string { return Mask(s, 1, 1, '*') }, val: "张小明", want: "张*明"},
+		{name: "mask rune", mask: func(s string) string { return Mask(s, 2, 0, '•') }, val: "secret", want: "se••••"},
+		{name: "negative keep", mask: func(s string) string { return Mask(s, -1, -1, '*') }, val: "abc", want: "***"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.mask(tc.val))
+		})
+	}
+}
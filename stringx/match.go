@@ -0,0 +1,45 @@
+package stringx
+
+import "strings"
+
+// ContainsAny reports whether s contains any of substrs, it stops at the first match.
+// Unlike strings.ContainsAny, substrs are strings rather than a set of runes.
+func ContainsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyPrefix reports whether s begins with any of prefixes, it stops at the first match.
+func HasAnyPrefix(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnySuffix reports whether s ends with any of suffixes, it stops at the first match.
+func HasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// EqualFoldAny reports whether s equals any of targets under Unicode case-folding,
+// it compares without allocating lowercased copies and stops at the first match.
+func EqualFoldAny(s string, targets ...string) bool {
+	for _, target := range targets {
+		if strings.EqualFold(s, target) {
+			return true
+		}
+	}
+	return false
+}
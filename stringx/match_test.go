@@ -0,0 +1,41 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchAny(t *testing.T) {
+	testCases := []struct {
+		name  string
+		match func(s string, targets ...string) bool
+		val   string
+		args  []string
+		want  bool
+	}{
+		{name: "contains", match: ContainsAny, val: "/api/v1/users", args: []string{"/admin", "/v1/"}, want: true},
+		{name: "not contains", match: ContainsAny, val: "/api/v1/users", args: []string{"/admin", "/v2/"}},
+		{name: "contains none", match: ContainsAny, val: "/api/v1/users"},
+		{name: "prefix", match: HasAnyPrefix, val: "/static/app.js", args: []string{"/api", "/static"}, want: true},
+		{name: "no prefix", match: HasAnyPrefix, val: "/static/app.js", args: []string{"/api"}},
+		{name: "suffix", match: HasAnySuffix, val: "app.min.js", args: []string{".css", ".js"}, want: true},
+		{name: "no suffix", match: HasAnySuffix, val: "app.min.js", args: []string{".css"}},
+		{name: "equal fold", match: EqualFoldAny, val: "Content-Type", args: []string{"accept", "content-type"}, want: true},
+		{name: "not equal fold", match: EqualFoldAny, val: "Content-Type", args: []string{"content-length"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.match(tc.val, tc.args...))
+		})
+	}
+}
+
+func BenchmarkEqualFoldAny(b *testing.B) {
+	targets := []string{"accept", "authorization", "content-length", "content-type"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		EqualFoldAny("Content-Type", targets...)
+	}
+}
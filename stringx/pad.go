@@ -0,0 +1,68 @@
+package stringx
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Width returns the display width of s in a terminal, wide characters such as CJK take 2 columns
+// and combining marks take none.
+func Width(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cc, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// PadLeft pads s with pad on the left until its display width reaches w, e.g. to right-align a column.
+func PadLeft(s string, w int, pad rune) string {
+	n := padCount(s, w, pad)
+	return strings.Repeat(string(pad), n) + s
+}
+
+// PadRight pads s with pad on the right until its display width reaches w, e.g. to left-align a column.
+func PadRight(s string, w int, pad rune) string {
+	n := padCount(s, w, pad)
+	return s + strings.Repeat(string(pad), n)
+}
+
+// Center pads s with pad on both sides until its display width reaches w,
+// the right side gets the extra pad if they can't be equal.
+func Center(s string, w int, pad rune) string {
+	n := padCount(s, w, pad)
+	left := n / 2
+	return strings.Repeat(string(pad), left) + s + strings.Repeat(string(pad), n-left)
+}
+
+// padCount returns the number of pad to add, which never makes the width exceed w
+func padCount(s string, w int, pad rune) int {
+	pw := runeWidth(pad)
+	if pw == 0 {
+		return 0
+	}
+	return max(w-Width(s), 0) / pw
+}
+
+// Reverse reverses s by runes, so that multibyte characters are not broken.
+func Reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,60 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWidth(t *testing.T) {
+	testCases := []struct {
+		name string
+		val  string
+		want int
+	}{
+		{name: "empty", val: "", want: 0},
+		{name: "ascii", val: "hello", want: 5},
+		{name: "cjk", val: "你好", want: 4},
+		{name: "fullwidth", val: "ＡＢ", want: 4},
+		{name: "mixed", val: "go语言", want: 6},
+		{name: "combining", val: "é", want: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Width(tc.val))
+		})
+	}
+}
+
+func TestPad(t *testing.T) {
+	testCases := []struct {
+		name string
+		pad  func(s string, w int, pad rune) string
+		val  string
+		w    int
+		r    rune
+		want string
+	}{
+		{name: "left", pad: PadLeft, val: "go", w: 5, r: ' ', want: "   go"},
+		{name: "left cjk", pad: PadLeft, val: "你好", w: 6, r: ' ', want: "  你好"},
+		{name: "right", pad: PadRight, val: "go", w: 5, r: '.', want: "go..."},
+		{name: "right cjk", pad: PadRight, val: "你好", w: 6, r: ' ', want: "你好  "},
+		{name: "center", pad: Center, val: "go", w: 5, r: '*', want: "*go**"},
+		{name: "center cjk", pad: Center, val: "你好", w: 8, r: ' ', want: "  你好  "},
+		{name: "wide pad", pad: PadLeft, val: "go", w: 7, r: '好', want: "好好go"},
+		{name: "too long", pad: PadRight, val: "你好世界", w: 4, r: ' ', want: "你好世界"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.pad(tc.val, tc.w, tc.r))
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	assert.Equal(t, "", Reverse(""))
+	assert.Equal(t, "olleh", Reverse("hello"))
+	assert.Equal(t, "界世好你", Reverse("你好世界"))
+}
@@ -0,0 +1,98 @@
+package stringx
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"math/big"
+	mrand "math/rand/v2"
+)
+
+const (
+	// Base62 is the charset of digits and letters, which is safe in URLs and file names
+	Base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// Hex is the charset of lowercase hexadecimal digits
+	Hex = "0123456789abcdef"
+)
+
+// Rand returns a string of n runes chosen from charset with a pseudo-random generator,
+// it is fast but predictable, use RandSecure for keys, tokens and nonces.
+func Rand(n int, charset string) string {
+	runes := []rune(charset)
+	if n <= 0 || len(runes) == 0 {
+		return ""
+	}
+	res := make([]rune, n)
+	for i := range res {
+		res[i] = runes[mrand.IntN(len(runes))]
+	}
+	return string(res)
+}
+
+// RandSecure returns a string of n runes chosen from Base62 with crypto/rand,
+// every rune is chosen with the same probability.
+func RandSecure(n int) (string, error) {
+	return RandSecureFrom(n, Base62)
+}
+
+// RandSecureFrom is like RandSecure but chooses the runes from charset.
+func RandSecureFrom(n int, charset string) (string, error) {
+	runes := []rune(charset)
+	if n <= 0 || len(runes) == 0 {
+		return "", nil
+	}
+	res := make([]rune, n)
+	limit := big.NewInt(int64(len(runes)))
+	for i := range res {
+		idx, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		res[i] = runes[idx.Int64()]
+	}
+	return string(res), nil
+}
+
+// HexToken returns n random bytes from crypto/rand encoded in hex, which has 2n characters.
+func HexToken(n int) (string, error) {
+	b, err := randBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Base62Token returns n random bytes from crypto/rand encoded with the digits of Base62,
+// which is shorter than HexToken for the same entropy, e.g. 22 characters for 16 bytes.
+// The length only depends on n, as the token is left-padded with '0'.
+func Base62Token(n int) (string, error) {
+	b, err := randBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase62(b), nil
+}
+
+// encodeBase62 encodes b as a big-endian number with the digits of Base62,
+// using as many characters as the largest number of len(b) bytes needs.
+// big.Int.Text can't be used, its digits are 0-9a-zA-Z and it drops the leading zeros.
+func encodeBase62(b []byte) string {
+	size := int(math.Ceil(float64(len(b)*8) / math.Log2(float64(len(Base62)))))
+	res := make([]byte, size)
+	x := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(Base62)))
+	mod := new(big.Int)
+	for i := size - 1; i >= 0; i-- {
+		x.DivMod(x, base, mod)
+		res[i] = Base62[mod.Int64()]
+	}
+	return string(res)
+}
+
+func randBytes(n int) ([]byte, error) {
+	b := make([]byte, max(n, 0))
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
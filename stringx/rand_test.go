@@ -0,0 +1,88 @@
+package stringx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		n       int
+		charset string
+		wantLen int
+	}{
+		{name: "base62", n: 16, charset: Base62, wantLen: 16},
+		{name: "unicode", n: 8, charset: "你好世界", wantLen: 8},
+		{name: "zero", n: 0, charset: Base62},
+		{name: "empty charset", n: 8, charset: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Rand(tc.n, tc.charset)
+			assert.Equal(t, tc.wantLen, utf8.RuneCountInString(got))
+			assertCharset(t, got, tc.charset)
+
+			got, err := RandSecureFrom(tc.n, tc.charset)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantLen, utf8.RuneCountInString(got))
+			assertCharset(t, got, tc.charset)
+		})
+	}
+
+	a, err := RandSecure(32)
+	require.NoError(t, err)
+	b, err := RandSecure(32)
+	require.NoError(t, err)
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+	assertCharset(t, a, Base62)
+}
+
+func TestToken(t *testing.T) {
+	token, err := HexToken(16)
+	require.NoError(t, err)
+	assert.Len(t, token, 32)
+	assertCharset(t, token, Hex)
+
+	token, err = Base62Token(16)
+	require.NoError(t, err)
+	assert.Len(t, token, 22)
+	assertCharset(t, token, Base62)
+
+	other, err := Base62Token(16)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestEncodeBase62(t *testing.T) {
+	testCases := []struct {
+		name string
+		b    []byte
+		want string
+	}{
+		{name: "空", b: nil, want: ""},
+		{name: "大写字母在小写字母前", b: []byte{10}, want: "0A"},
+		{name: "最大值", b: []byte{0xff}, want: "47"},
+		{name: "保留前导零", b: []byte{0, 0}, want: "000"},
+		{name: "16 字节", b: bytes.Repeat([]byte{0xff}, 16), want: "7n42DGM5Tflk9n8mt7Fhc7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, encodeBase62(tc.b))
+		})
+	}
+}
+
+func assertCharset(t *testing.T, s, charset string) {
+	for _, r := range s {
+		assert.True(t, strings.ContainsRune(charset, r), "unexpected rune %q", r)
+	}
+}
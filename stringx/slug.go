@@ -0,0 +1,93 @@
+package stringx
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ecloudclub/zkit/option"
+	"golang.org/x/text/unicode/norm"
+)
+
+type slugger struct {
+	maxLength int
+	allowed   string
+}
+
+type SlugOption = option.Option[slugger]
+
+// WithMaxLength limits the length of slugs in bytes, slugs are cut at a hyphen if possible.
+func WithMaxLength(n int) SlugOption {
+	return func(s *slugger) {
+		s.maxLength = n
+	}
+}
+
+// WithAllowedChars keeps the characters in chars besides letters and digits, e.g. "_." to keep
+// underscores and dots which are replaced with hyphens by default.
+func WithAllowedChars(chars string) SlugOption {
+	return func(s *slugger) {
+		s.allowed = chars
+	}
+}
+
+// Slug returns a URL-safe slug of s, e.g. "Héllo, Wörld!" becomes "hello-world".
+// Letters are lowercased and transliterated to ASCII, accents are removed and Cyrillic and Greek
+// are romanized, other characters are replaced with a single hyphen and trimmed at both ends.
+// Letters of scripts which can't be transliterated, such as CJK, are replaced as well.
+func Slug(s string, opts ...SlugOption) string {
+	var sl slugger
+	option.Apply(&sl, opts...)
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	hyphen := false
+	write := func(str string) {
+		if hyphen && sb.Len() > 0 {
+			sb.WriteByte('-')
+		}
+		hyphen = false
+		sb.WriteString(str)
+	}
+	// NFD decomposes accented letters into the base letter and combining marks
+	for _, r := range norm.NFD.String(s) {
+		r = unicode.ToLower(r)
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(r))
+		case strings.ContainsRune(sl.allowed, r):
+			write(string(r))
+		case unicode.Is(unicode.Mn, r):
+			// drops the accents
+		default:
+			if t, ok := transliterations[r]; ok {
+				write(t)
+			} else {
+				hyphen = true
+			}
+		}
+	}
+
+	slug := sb.String()
+	if sl.maxLength > 0 && len(slug) > sl.maxLength {
+		slug = slug[:sl.maxLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	return slug
+}
+
+// transliterations romanizes the letters which are not decomposed by NFD
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
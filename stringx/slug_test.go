@@ -0,0 +1,34 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlug(t *testing.T) {
+	testCases := []struct {
+		name string
+		val  string
+		opts []SlugOption
+		want string
+	}{
+		{name: "ascii", val: "Hello, World!", want: "hello-world"},
+		{name: "repeats", val: "  --Hello   World--  ", want: "hello-world"},
+		{name: "accents", val: "Héllo Wörld Çà ñ", want: "hello-world-ca-n"},
+		{name: "latin", val: "Straße Æsir Łódź", want: "strasse-aesir-lodz"},
+		{name: "cyrillic", val: "Привет мир", want: "privet-mir"},
+		{name: "greek", val: "Καλημέρα κόσμε", want: "kalimera-kosme"},
+		{name: "cjk", val: "Go 语言 2024", want: "go-2024"},
+		{name: "empty", val: "!!!", want: ""},
+		{name: "max length at hyphen", val: "hello wonderful world", opts: []SlugOption{WithMaxLength(18)}, want: "hello-wonderful"},
+		{name: "max length in word", val: "helloworld", opts: []SlugOption{WithMaxLength(5)}, want: "hello"},
+		{name: "allowed chars", val: "v1.2_beta release", opts: []SlugOption{WithAllowedChars("._")}, want: "v1.2_beta-release"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Slug(tc.val, tc.opts...))
+		})
+	}
+}
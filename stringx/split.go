@@ -0,0 +1,71 @@
+package stringx
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrUnclosedQuote    = errors.New("zkit: 引号未闭合")
+	ErrUnfinishedEscape = errors.New("zkit: 转义字符后缺少字符")
+)
+
+// SplitQuoted splits s on whitespace like a shell, e.g. `run -m "hello world"` becomes
+// ["run", "-m", "hello world"]. Quotes are removed and quoted whitespace is kept:
+//   - everything in single quotes is literal
+//   - a backslash in double quotes escapes only " and \
+//   - a backslash out of quotes escapes any character
+//
+// Adjacent quoted and unquoted parts make a single field, and "" makes an empty one.
+// Variables, globs and other expansions are not supported.
+func SplitQuoted(s string) ([]string, error) {
+	var (
+		fields  []string
+		sb      strings.Builder
+		inField bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			if quote == '"' && r != '"' && r != '\\' {
+				sb.WriteRune('\\')
+			}
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inField = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				sb.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inField = true
+		case unicode.IsSpace(r):
+			if inField {
+				fields = append(fields, sb.String())
+				sb.Reset()
+				inField = false
+			}
+		default:
+			sb.WriteRune(r)
+			inField = true
+		}
+	}
+	if escaped {
+		return nil, ErrUnfinishedEscape
+	}
+	if quote != 0 {
+		return nil, ErrUnclosedQuote
+	}
+	if inField {
+		fields = append(fields, sb.String())
+	}
+	return fields, nil
+}
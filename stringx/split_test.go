@@ -0,0 +1,37 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitQuoted(t *testing.T) {
+	testCases := []struct {
+		name    string
+		val     string
+		want    []string
+		wantErr error
+	}{
+		{name: "empty", val: "  "},
+		{name: "plain", val: " run  -v\tfile ", want: []string{"run", "-v", "file"}},
+		{name: "double quotes", val: `echo "hello world"`, want: []string{"echo", "hello world"}},
+		{name: "single quotes", val: `echo 'a "b" \c'`, want: []string{"echo", `a "b" \c`}},
+		{name: "escape in double quotes", val: `"a \"b\" \\ \n"`, want: []string{`a "b" \ \n`}},
+		{name: "escape out of quotes", val: `hello\ world \'a\'`, want: []string{"hello world", "'a'"}},
+		{name: "adjacent parts", val: `--name="zkit go"'!'`, want: []string{"--name=zkit go!"}},
+		{name: "empty field", val: `a "" ''`, want: []string{"a", "", ""}},
+		{name: "unicode", val: `搜索 "你好 世界"`, want: []string{"搜索", "你好 世界"}},
+		{name: "unclosed quote", val: `echo "hello`, wantErr: ErrUnclosedQuote},
+		{name: "unclosed single quote", val: `echo 'hello`, wantErr: ErrUnclosedQuote},
+		{name: "unfinished escape", val: `echo \`, wantErr: ErrUnfinishedEscape},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SplitQuoted(tc.val)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
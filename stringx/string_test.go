@@ -0,0 +1,46 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsafeToBytes(t *testing.T) {
+	testCases := []struct {
+		name string
+		val  string
+		want []byte
+	}{
+		{name: "empty", val: "", want: []byte{}},
+		{name: "ascii", val: "hello", want: []byte("hello")},
+		{name: "unicode", val: "你好", want: []byte("你好")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := UnsafeToBytes(tc.val)
+			assert.Equal(t, len(tc.want), len(got))
+			assert.Equal(t, string(tc.want), string(got))
+		})
+	}
+}
+
+func TestUnsafeToString(t *testing.T) {
+	testCases := []struct {
+		name string
+		val  []byte
+		want string
+	}{
+		{name: "nil", val: nil, want: ""},
+		{name: "ascii", val: []byte("hello"), want: "hello"},
+		{name: "unicode", val: []byte("你好"), want: "你好"},
+		{name: "sub slice", val: []byte("hello world")[6:], want: "world"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, UnsafeToString(tc.val))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package stringx
+
+import (
+	"unicode/utf8"
+
+	"github.com/ecloudclub/zkit/option"
+)
+
+type truncator struct {
+	ellipsis string
+}
+
+type TruncateOption = option.Option[truncator]
+
+// WithEllipsis appends ellipsis to truncated strings, e.g. "..." or "…",
+// it counts towards the limit and is left out if the limit is too small for it.
+func WithEllipsis(ellipsis string) TruncateOption {
+	return func(t *truncator) {
+		t.ellipsis = ellipsis
+	}
+}
+
+// Truncate shortens s to at most maxRunes runes, s is returned as is if it is short enough.
+func Truncate(s string, maxRunes int, opts ...TruncateOption) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	var t truncator
+	option.Apply(&t, opts...)
+	if n := utf8.RuneCountInString(t.ellipsis); n <= maxRunes {
+		maxRunes -= n
+	} else {
+		t.ellipsis = ""
+	}
+	return s[:runeOffset(s, maxRunes)] + t.ellipsis
+}
+
+// TruncateBytes shortens s to at most maxBytes bytes without splitting a UTF-8 sequence,
+// s is returned as is if it is short enough.
+func TruncateBytes(s string, maxBytes int, opts ...TruncateOption) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	var t truncator
+	option.Apply(&t, opts...)
+	if len(t.ellipsis) <= maxBytes {
+		maxBytes -= len(t.ellipsis)
+	} else {
+		t.ellipsis = ""
+	}
+	end := max(maxBytes, 0)
+	// steps back to the start of the rune crossing the limit
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + t.ellipsis
+}
+
+// runeOffset returns the byte offset of the n-th rune of s
+func runeOffset(s string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
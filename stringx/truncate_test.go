@@ -0,0 +1,58 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		val      string
+		maxRunes int
+		opts     []TruncateOption
+		want     string
+	}{
+		{name: "short", val: "hello", maxRunes: 5, want: "hello"},
+		{name: "ascii", val: "hello world", maxRunes: 5, want: "hello"},
+		{name: "unicode", val: "你好世界", maxRunes: 2, want: "你好"},
+		{name: "ellipsis", val: "你好世界", maxRunes: 3, opts: []TruncateOption{WithEllipsis("…")}, want: "你好…"},
+		{name: "short with ellipsis", val: "你好", maxRunes: 2, opts: []TruncateOption{WithEllipsis("…")}, want: "你好"},
+		{name: "ellipsis too long", val: "hello world", maxRunes: 2, opts: []TruncateOption{WithEllipsis("...")}, want: "he"},
+		{name: "zero", val: "hello", maxRunes: 0, want: ""},
+		{name: "negative", val: "hello", maxRunes: -1, want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Truncate(tc.val, tc.maxRunes, tc.opts...))
+		})
+	}
+}
+
+func TestTruncateBytes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		val      string
+		maxBytes int
+		opts     []TruncateOption
+		want     string
+	}{
+		{name: "short", val: "hello", maxBytes: 5, want: "hello"},
+		{name: "ascii", val: "hello world", maxBytes: 5, want: "hello"},
+		{name: "rune boundary", val: "你好世界", maxBytes: 6, want: "你好"},
+		{name: "inside rune", val: "你好世界", maxBytes: 8, want: "你好"},
+		{name: "ellipsis", val: "你好世界", maxBytes: 10, opts: []TruncateOption{WithEllipsis("...")}, want: "你好..."},
+		{name: "inside rune with ellipsis", val: "你好世界", maxBytes: 8, opts: []TruncateOption{WithEllipsis("...")}, want: "你..."},
+		{name: "ellipsis too long", val: "hello", maxBytes: 2, opts: []TruncateOption{WithEllipsis("...")}, want: "he"},
+		{name: "zero", val: "你好", maxBytes: 0, want: ""},
+		{name: "negative", val: "你好", maxBytes: -1, want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, TruncateBytes(tc.val, tc.maxBytes, tc.opts...))
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package zapx
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/ecloudclub/zkit/reflectx"
+	"go.uber.org/zap/zapcore"
+)
+
+// maskedObject masks the fields of obj while it is being encoded
+type maskedObject struct {
+	obj     zapcore.ObjectMarshaler
+	maskers map[string]Masker
+}
+
+func (o maskedObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.obj.MarshalLogObject(&maskingEncoder{ObjectEncoder: enc, maskers: o.maskers})
+}
+
+// maskedArray masks the elements of arr while it is being encoded,
+// its strings are masked by the masker of the key of the array
+type maskedArray struct {
+	arr     zapcore.ArrayMarshaler
+	maskers map[string]Masker
+	masker  Masker
+}
+
+func (a maskedArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return a.arr.MarshalLogArray(&maskingArrayEncoder{ArrayEncoder: enc, maskers: a.maskers, masker: a.masker})
+}
+
+type maskingEncoder struct {
+	zapcore.ObjectEncoder
+	maskers map[string]Masker
+}
+
+func (e *maskingEncoder) AddString(key, val string) {
+	if m, ok := e.maskers[key]; ok {
+		val = m(val)
+	}
+	e.ObjectEncoder.AddString(key, val)
+}
+
+func (e *maskingEncoder) AddByteString(key string, val []byte) {
+	if m, ok := e.maskers[key]; ok {
+		val = []byte(m(string(val)))
+	}
+	e.ObjectEncoder.AddByteString(key, val)
+}
+
+func (e *maskingEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	return e.ObjectEncoder.AddObject(key, maskedObject{obj: obj, maskers: e.maskers})
+}
+
+func (e *maskingEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	return e.ObjectEncoder.AddArray(key, maskedArray{arr: arr, maskers: e.maskers, masker: e.maskers[key]})
+}
+
+func (e *maskingEncoder) AddReflected(key string, val any) error {
+	return e.ObjectEncoder.AddReflected(key, maskReflected(val, e.maskers, e.maskers[key]))
+}
+
+type maskingArrayEncoder struct {
+	zapcore.ArrayEncoder
+	maskers map[string]Masker
+	masker  Masker
+}
+
+func (e *maskingArrayEncoder) AppendString(val string) {
+	if e.masker != nil {
+		val = e.masker(val)
+	}
+	e.ArrayEncoder.AppendString(val)
+}
+
+func (e *maskingArrayEncoder) AppendByteString(val []byte) {
+	if e.masker != nil {
+		val = []byte(e.masker(string(val)))
+	}
+	e.ArrayEncoder.AppendByteString(val)
+}
+
+func (e *maskingArrayEncoder) AppendObject(obj zapcore.ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(maskedObject{obj: obj, maskers: e.maskers})
+}
+
+func (e *maskingArrayEncoder) AppendArray(arr zapcore.ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(maskedArray{arr: arr, maskers: e.maskers, masker: e.masker})
+}
+
+func (e *maskingArrayEncoder) AppendReflected(val any) error {
+	return e.ArrayEncoder.AppendReflected(maskReflected(val, e.maskers, e.masker))
+}
+
+// maskReflected converts val, such as a struct or a map, into its JSON form and masks the strings
+// whose keys have maskers, the strings in arrays are masked by the masker of the key of the array.
+// val is returned as it is if none of its strings can be masked, so that it is encoded by
+// the configured ReflectedEncoder without the overhead, or if it can't be converted,
+// which fails to be encoded anyway.
+func maskReflected(val any, maskers map[string]Masker, masker Masker) any {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case string:
+		if masker != nil {
+			return masker(v)
+		}
+		return v
+	}
+	if !shapeOf(reflect.TypeOf(val)).maskable(maskers, masker) {
+		return val
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return val
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// keeps the numbers as they are, e.g. int64 larger than 2^53
+	dec.UseNumber()
+	var res any
+	if err = dec.Decode(&res); err != nil {
+		return val
+	}
+	return maskValue(res, maskers, masker)
+}
+
+func maskValue(val any, maskers map[string]Masker, masker Masker) any {
+	switch v := val.(type) {
+	case string:
+		if masker != nil {
+			return masker(v)
+		}
+	case map[string]any:
+		for key, elem := range v {
+			v[key] = maskValue(elem, maskers, maskers[key])
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = maskValue(elem, maskers, masker)
+		}
+	}
+	return val
+}
+
+// jsonShape describes the JSON form of a type, as far as masking is concerned.
+type jsonShape struct {
+	// keys are the object keys whose values may be strings
+	keys map[string]struct{}
+	// strings reports whether strings may appear outside objects, e.g. in arrays
+	strings bool
+	// dynamic reports whether the keys can't be known from the type,
+	// e.g. maps, interfaces and types with their own MarshalJSON
+	dynamic bool
+}
+
+var shapeCache sync.Map // reflect.Type -> *jsonShape
+
+// shapeOf returns the cached shape of the JSON form of typ.
+func shapeOf(typ reflect.Type) *jsonShape {
+	if shape, ok := shapeCache.Load(typ); ok {
+		return shape.(*jsonShape)
+	}
+	shape := &jsonShape{keys: make(map[string]struct{})}
+	shape.walk(typ, make(map[reflect.Type]bool))
+	actual, _ := shapeCache.LoadOrStore(typ, shape)
+	return actual.(*jsonShape)
+}
+
+// maskable reports whether a value of the shape may have strings to be masked.
+func (s *jsonShape) maskable(maskers map[string]Masker, masker Masker) bool {
+	if s.dynamic {
+		return len(maskers) > 0 || masker != nil
+	}
+	if masker != nil && s.strings {
+		return true
+	}
+	for key := range maskers {
+		if _, ok := s.keys[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// walk adds the keys of typ and of the types it contains to the shape,
+// seen stops the recursion of recursive types.
+func (s *jsonShape) walk(typ reflect.Type, seen map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	switch {
+	case typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(jsonMarshalerType):
+		if !typ.Implements(textMarshalerType) && !reflect.PointerTo(typ).Implements(textMarshalerType) {
+			s.dynamic = true
+			return
+		}
+		// Such as time.Time, they are encoded as strings
+		s.strings = true
+		return
+	case typ.Implements(textMarshalerType) || reflect.PointerTo(typ).Implements(textMarshalerType):
+		s.strings = true
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		s.strings = true
+	case reflect.Interface:
+		s.dynamic = true
+	case reflect.Map:
+		if holdsString(typ.Elem()) {
+			s.dynamic = true
+		}
+		s.walk(typ.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string, which is never masked
+			return
+		}
+		s.walk(typ.Elem(), seen)
+	case reflect.Struct:
+		for _, field := range reflectx.StructFields(typ, "json") {
+			if field.Embedded() {
+				s.walk(field.Type, seen)
+				continue
+			}
+			if !field.IsExported() || field.Tag.Ignored {
+				continue
+			}
+			key := field.Tag.Name
+			if key == "" {
+				key = field.Name
+			}
+			// Numbers and booleans tagged with the string option are encoded as strings too
+			if holdsString(field.Type) || field.Tag.Has("string") {
+				s.keys[key] = struct{}{}
+			}
+			s.walk(field.Type, seen)
+		}
+	}
+}
+
+// holdsString reports whether a value of typ may be a string or an array of strings,
+// which are masked by the masker of its key.
+func holdsString(typ reflect.Type) bool {
+	for {
+		switch typ.Kind() {
+		case reflect.Pointer:
+			typ = typ.Elem()
+		case reflect.Slice, reflect.Array:
+			if typ.Elem().Kind() == reflect.Uint8 {
+				return false
+			}
+			typ = typ.Elem()
+		case reflect.String, reflect.Interface:
+			return true
+		default:
+			return typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) ||
+				reflect.PointerTo(typ).Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(textMarshalerType)
+		}
+	}
+}
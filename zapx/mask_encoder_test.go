@@ -0,0 +1,198 @@
+package zapx
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type contact struct {
+	Phone string `json:"phone"`
+	Email string `json:"email"`
+}
+
+func (c contact) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("phone", c.Phone)
+	enc.AddString("email", c.Email)
+	return enc.AddReflected("backup", map[string]string{"phone": c.Phone})
+}
+
+type account struct {
+	ID       int64     `json:"id"`
+	Contact  contact   `json:"contact"`
+	Contacts []contact `json:"contacts"`
+	Phones   []string  `json:"phone"`
+}
+
+func newTestLogger(buf *bytes.Buffer) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	core := zapcore.NewCore(enc, zapcore.AddSync(buf), zapcore.InfoLevel)
+	return zap.New(NewCustomCore(core, WithMaskers("phone", "email")))
+}
+
+func TestCustomCore_Nested(t *testing.T) {
+	c := contact{Phone: "13117127078", Email: "zkit@example.com"}
+	testCases := []struct {
+		name   string
+		fields []zap.Field
+		want   string
+	}{
+		{
+			name:   "Object",
+			fields: []zap.Field{zap.Object("user", c)},
+			want:   `{"msg":"msg","user":{"phone":"131****7078","email":"z***@example.com","backup":{"phone":"131****7078"}}}`,
+		},
+		{
+			name:   "Objects",
+			fields: []zap.Field{zap.Objects("users", []contact{c})},
+			want:   `{"msg":"msg","users":[{"phone":"131****7078","email":"z***@example.com","backup":{"phone":"131****7078"}}]}`,
+		},
+		{
+			name:   "Strings",
+			fields: []zap.Field{zap.Strings("phone", []string{"13117127078", "12"})},
+			want:   `{"msg":"msg","phone":["131****7078","**"]}`,
+		},
+		{
+			name:   "Any 结构体",
+			fields: []zap.Field{zap.Any("account", account{ID: 1 << 60, Contact: c, Contacts: []contact{c}, Phones: []string{"13117127078"}})},
+			want: `{"msg":"msg","account":{"contact":{"email":"z***@example.com","phone":"131****7078"},` +
+				`"contacts":[{"email":"z***@example.com","phone":"131****7078"}],"id":1152921504606846976,"phone":["131****7078"]}}`,
+		},
+		{
+			name:   "Any map",
+			fields: []zap.Field{zap.Any("claims", map[string]any{"sub": "1", "phone": "13117127078"})},
+			want:   `{"msg":"msg","claims":{"phone":"131****7078","sub":"1"}}`,
+		},
+		{
+			name:   "Namespace",
+			fields: []zap.Field{zap.Namespace("user"), zap.String("phone", "13117127078")},
+			want:   `{"msg":"msg","user":{"phone":"131****7078"}}`,
+		},
+		{
+			name:   "Stringer",
+			fields: []zap.Field{zap.Stringer("email", bytes.NewBufferString("zkit@example.com"))},
+			want:   `{"msg":"msg","email":"z***@example.com"}`,
+		},
+		{
+			name:   "ByteString",
+			fields: []zap.Field{zap.ByteString("phone", []byte("13117127078"))},
+			want:   `{"msg":"msg","phone":"131****7078"}`,
+		},
+		{
+			name:   "ByteStrings",
+			fields: []zap.Field{zap.ByteStrings("phone", [][]byte{[]byte("13117127078")})},
+			want:   `{"msg":"msg","phone":["131****7078"]}`,
+		},
+		{
+			name: "Object ByteString",
+			fields: []zap.Field{zap.Object("user", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddByteString("phone", []byte("13117127078"))
+				return nil
+			}))},
+			want: `{"msg":"msg","user":{"phone":"131****7078"}}`,
+		},
+		{
+			name:   "数字不脱敏",
+			fields: []zap.Field{zap.Int64("phone", 13117127078)},
+			want:   `{"msg":"msg","phone":13117127078}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			newTestLogger(&buf).Info("msg", tc.fields...)
+			assert.JSONEq(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestCustomCore_With(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(&buf).With(zap.String("phone", "13117127078")).With(zap.Namespace("req"))
+	l.Info("msg", zap.String("email", "zkit@example.com"))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, map[string]any{
+		"msg":   "msg",
+		"phone": "131****7078",
+		"req":   map[string]any{"email": "z***@example.com"},
+	}, got)
+}
+
+type recursive struct {
+	Name     string       `json:"name"`
+	Children []*recursive `json:"children"`
+}
+
+func TestMaskReflected(t *testing.T) {
+	maskers := map[string]Masker{"phone": builtinMaskers["phone"]}
+	testCases := []struct {
+		name   string
+		val    any
+		masker Masker
+		// wantMasked reports whether the value is rewritten into its masked JSON form
+		wantMasked bool
+	}{
+		{name: "没有需要脱敏的字段", val: struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}{ID: 1, Name: "Tom"}},
+		{name: "字段名匹配但不是字符串", val: struct {
+			Phone int `json:"phone"`
+		}{Phone: 1}},
+		{name: "忽略的字段", val: struct {
+			Phone string `json:"-"`
+		}{Phone: "13117127078"}},
+		{name: "递归的类型", val: recursive{Name: "Tom"}},
+		{name: "[]byte", val: []byte("13117127078"), masker: builtinMaskers["phone"]},
+		{name: "嵌套的字段", val: account{}, wantMasked: true},
+		{name: "string 选项", val: struct {
+			Phone int64 `json:"phone,string"`
+		}{Phone: 1}, wantMasked: true},
+		{name: "数组使用 key 的脱敏", val: []string{"13117127078"}, masker: builtinMaskers["phone"], wantMasked: true},
+		{name: "map", val: map[string]string{"name": "Tom"}, wantMasked: true},
+		{name: "interface", val: []any{"Tom"}, masker: builtinMaskers["phone"], wantMasked: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := maskReflected(tc.val, maskers, tc.masker)
+			if tc.wantMasked {
+				assert.NotEqual(t, tc.val, res)
+				return
+			}
+			assert.Equal(t, tc.val, res)
+		})
+	}
+}
+
+func TestCustomCore_ReflectedEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey: "msg",
+		NewReflectedEncoder: func(w io.Writer) zapcore.ReflectedEncoder {
+			return reflectedEncoderFunc(func(v any) error {
+				_, err := w.Write([]byte(`"custom"`))
+				return err
+			})
+		},
+	})
+	core := zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.InfoLevel)
+	// values without strings to mask are still encoded by the configured encoder
+	zap.New(NewCustomCore(core)).Info("msg", zap.Any("user", struct{ Name string }{Name: "Tom"}))
+	assert.JSONEq(t, `{"msg":"msg","user":"custom"}`, buf.String())
+}
+
+type reflectedEncoderFunc func(v any) error
+
+func (f reflectedEncoderFunc) Encode(v any) error {
+	return f(v)
+}
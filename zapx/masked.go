@@ -0,0 +1,193 @@
+package zapx
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/ecloudclub/zkit/reflectx"
+	"go.uber.org/zap/zapcore"
+)
+
+// maskedValue is the value of the fields tagged `log:"mask"`, which hides even the length.
+const maskedValue = "******"
+
+var (
+	objectMarshalerType = reflect.TypeFor[zapcore.ObjectMarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType   = reflect.TypeFor[json.Marshaler]()
+)
+
+// Masked returns an ObjectMarshaler logging the struct v, or a pointer to it, with the sensitive fields
+// masked according to their tags, so that the PII policy is defined along with the models, e.g.
+//
+//	type User struct {
+//		Name     string `json:"name"`
+//		Phone    string `json:"phone" mask:"phone"`
+//		Password string `log:"-"`
+//		Token    string `log:"mask"`
+//	}
+//
+//	logger.Info("login", zap.Object("user", zapx.Masked(user)))
+//
+// `mask:"name"` masks a field by the built-in masker of name, see WithMaskers, and fields tagged with
+// an unknown name are masked entirely. `log:"mask"` replaces a field with ******, and `log:"-"` omits it.
+// The keys are the names in the json tags or the field names. Nested structs, pointers to them and
+// slices of them are masked recursively, and the strings in slices are masked one by one.
+// Like encoding/json, a value referencing itself through pointers fails with ErrMaskedCycle.
+func Masked(v any) zapcore.ObjectMarshaler {
+	return maskedStruct{val: reflect.ValueOf(v), seen: make(map[visit]struct{})}
+}
+
+// ErrMaskedCycle is returned when the value passed to Masked references itself.
+var ErrMaskedCycle = errors.New("zkit: 日志对象存在循环引用")
+
+// visit is a pointer being marshaled, the type distinguishes a struct from its first field
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type maskedStruct struct {
+	val reflect.Value
+	// seen holds the pointers on the path from the root to val
+	seen map[visit]struct{}
+}
+
+func (s maskedStruct) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for v := s.val; v.Kind() == reflect.Pointer && !v.IsNil(); v = v.Elem() {
+		key := visit{ptr: v.Pointer(), typ: v.Type()}
+		if _, ok := s.seen[key]; ok {
+			return ErrMaskedCycle
+		}
+		s.seen[key] = struct{}{}
+		defer delete(s.seen, key)
+	}
+
+	val := indirect(s.val)
+	if val.Kind() != reflect.Struct {
+		if val.IsValid() {
+			return enc.AddReflected("value", val.Interface())
+		}
+		return nil
+	}
+
+	for _, field := range reflectx.StructFields(val.Type(), "json") {
+		fv := val.FieldByIndex(field.Index)
+		if field.Embedded() {
+			if fv = indirect(fv); fv.IsValid() {
+				if err := (maskedStruct{val: fv, seen: s.seen}).MarshalLogObject(enc); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		logTag := field.StructField.Tag.Get("log")
+		if !field.IsExported() || field.Tag.Ignored || logTag == "-" {
+			continue
+		}
+		key := field.Tag.Name
+		if key == "" {
+			key = field.Name
+		}
+
+		if logTag == "mask" {
+			enc.AddString(key, maskedValue)
+			continue
+		}
+		var masker Masker
+		if name, ok := field.StructField.Tag.Lookup("mask"); ok {
+			if masker = builtinMaskers[name]; masker == nil {
+				enc.AddString(key, maskedValue)
+				continue
+			}
+		}
+		if err := s.addMasked(enc, key, fv, masker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMasked adds val to enc with strings masked by masker if it is not nil.
+func (s maskedStruct) addMasked(enc zapcore.ObjectEncoder, key string, val reflect.Value, masker Masker) error {
+	if (val.Kind() == reflect.Pointer || val.Kind() == reflect.Slice) && val.IsNil() {
+		return enc.AddReflected(key, nil)
+	}
+	if masker != nil {
+		if s := indirect(val); s.Kind() == reflect.String {
+			enc.AddString(key, masker(s.String()))
+			return nil
+		}
+	}
+	if isMaskedStruct(val) {
+		return enc.AddObject(key, maskedStruct{val: val, seen: s.seen})
+	}
+	switch val.Kind() {
+	case reflect.String:
+		enc.AddString(key, val.String())
+	case reflect.Slice, reflect.Array:
+		elem := val.Type().Elem()
+		if elem.Kind() == reflect.Uint8 || masker == nil && !isMaskedStructType(elem) {
+			return enc.AddReflected(key, val.Interface())
+		}
+		return enc.AddArray(key, maskedSlice{val: val, masker: masker, seen: s.seen})
+	default:
+		return enc.AddReflected(key, val.Interface())
+	}
+	return nil
+}
+
+type maskedSlice struct {
+	val    reflect.Value
+	masker Masker
+	seen   map[visit]struct{}
+}
+
+func (s maskedSlice) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for i := 0; i < s.val.Len(); i++ {
+		elem := s.val.Index(i)
+		switch {
+		case isMaskedStruct(elem):
+			if err := enc.AppendObject(maskedStruct{val: elem, seen: s.seen}); err != nil {
+				return err
+			}
+		case s.masker != nil && elem.Kind() == reflect.String:
+			enc.AppendString(s.masker(elem.String()))
+		default:
+			if err := enc.AppendReflected(elem.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isMaskedStruct reports whether val is a non-nil struct, or a pointer to it, masked field by field.
+func isMaskedStruct(val reflect.Value) bool {
+	return indirect(val).IsValid() && isMaskedStructType(val.Type())
+}
+
+// isMaskedStructType excludes the structs marshaling themselves, e.g. time.Time.
+func isMaskedStructType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	ptr := reflect.PointerTo(typ)
+	return !ptr.Implements(objectMarshalerType) && !ptr.Implements(textMarshalerType) && !ptr.Implements(jsonMarshalerType)
+}
+
+// indirect dereferences the pointers and interfaces in val, it returns the zero Value for nil.
+func indirect(val reflect.Value) reflect.Value {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return reflect.Value{}
+		}
+		val = val.Elem()
+	}
+	return val
+}
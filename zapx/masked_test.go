@@ -0,0 +1,131 @@
+package zapx
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type Profile struct {
+	Email  string   `json:"email" mask:"email"`
+	Phones []string `json:"phones" mask:"phone"`
+}
+
+type Model struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Member struct {
+	Model
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Phone    string            `json:"phone" mask:"phone"`
+	IDCard   *string           `json:"id_card" mask:"idcard"`
+	Password string            `log:"-"`
+	Token    string            `json:"token" log:"mask"`
+	Secret   int               `json:"secret" mask:"unknown"`
+	Profile  *Profile          `json:"profile"`
+	Friends  []Profile         `json:"friends"`
+	Labels   map[string]string `json:"labels"`
+	Ignored  string            `json:"-"`
+	note     string
+}
+
+func TestMasked(t *testing.T) {
+	idCard := "110101199003071234"
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := Member{
+		Model:    Model{CreatedAt: created},
+		ID:       1,
+		Name:     "Tom",
+		Phone:    "13117127078",
+		IDCard:   &idCard,
+		Password: "123456",
+		Token:    "abc",
+		Secret:   42,
+		Profile:  &Profile{Email: "zkit@example.com", Phones: []string{"13117127078", "123"}},
+		Friends:  []Profile{{Email: "a@example.com"}},
+		Labels:   map[string]string{"k": "v"},
+		Ignored:  "ignored",
+		note:     "note",
+	}
+
+	testCases := []struct {
+		name  string
+		field zap.Field
+		want  string
+	}{
+		{
+			name:  "结构体",
+			field: zap.Object("member", Masked(m)),
+			want: `{"msg":"msg","member":{"created_at":"2025-01-01T00:00:00Z","id":1,"name":"Tom","phone":"131****7078",` +
+				`"id_card":"110***********1234","token":"******","secret":"******",` +
+				`"profile":{"email":"z***@example.com","phones":["131****7078","***"]},` +
+				`"friends":[{"email":"*@example.com","phones":null}],"labels":{"k":"v"}}}`,
+		},
+		{
+			name:  "指针和 nil 字段",
+			field: zap.Object("member", Masked(&Member{Name: "Jerry"})),
+			want: `{"msg":"msg","member":{"created_at":"0001-01-01T00:00:00Z","id":0,"name":"Jerry","phone":"",` +
+				`"id_card":null,"token":"******","secret":"******","profile":null,"friends":null,"labels":null}}`,
+		},
+		{
+			name:  "nil",
+			field: zap.Object("member", Masked((*Member)(nil))),
+			want:  `{"msg":"msg","member":{}}`,
+		},
+		{
+			name:  "非结构体",
+			field: zap.Object("val", Masked(1)),
+			want:  `{"msg":"msg","val":{"value":1}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", EncodeTime: zapcore.RFC3339TimeEncoder})
+			l := zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.InfoLevel))
+			l.Info("msg", tc.field)
+			assert.JSONEq(t, tc.want, buf.String())
+		})
+	}
+}
+
+type treeNode struct {
+	Name     string      `json:"name"`
+	Parent   *treeNode   `json:"parent"`
+	Children []*treeNode `json:"children"`
+}
+
+func TestMasked_Cycle(t *testing.T) {
+	root := &treeNode{Name: "root"}
+	child := &treeNode{Name: "child", Parent: root}
+	root.Children = []*treeNode{child}
+	self := &treeNode{Name: "self"}
+	self.Parent = self
+	// the same pointer twice without a cycle is fine
+	shared := &treeNode{Name: "shared"}
+	siblings := &treeNode{Name: "siblings", Children: []*treeNode{shared, shared}}
+
+	testCases := []struct {
+		name    string
+		val     any
+		wantErr error
+	}{
+		{name: "self", val: self, wantErr: ErrMaskedCycle},
+		{name: "parent", val: root, wantErr: ErrMaskedCycle},
+		{name: "shared", val: siblings},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			err := Masked(tc.val).MarshalLogObject(enc)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package zapx
+
+import (
+	"net"
+	"strings"
+
+	"github.com/ecloudclub/zkit/stringx"
+)
+
+// Masker masks a sensitive value before it is logged.
+type Masker func(val string) string
+
+// builtinMaskers are the maskers selectable by name with WithMaskers
+var builtinMaskers = map[string]Masker{
+	"phone":    stringx.MaskPhone,
+	"email":    stringx.MaskEmail,
+	"idcard":   stringx.MaskIDCard,
+	"bankcard": stringx.MaskBankCard,
+	"ip":       MaskIP,
+	"jwt":      MaskJWT,
+	"apikey":   MaskAPIKey,
+	"password": func(string) string { return maskedValue },
+}
+
+// MaskIP masks the host part of an IP address, e.g. 192.168.*.* for IPv4 and 2001:db8:*:* for IPv6,
+// values which are not IP addresses are masked entirely.
+func MaskIP(val string) string {
+	ip := net.ParseIP(val)
+	switch {
+	case ip == nil:
+		return stringx.Mask(val, 0, 0, '*')
+	case ip.To4() != nil:
+		parts := strings.Split(ip.To4().String(), ".")
+		return parts[0] + "." + parts[1] + ".*.*"
+	default:
+		parts := strings.SplitN(ip.String(), ":", 3)
+		if len(parts) < 3 || parts[1] == "" {
+			// e.g. ::1, the leading groups are zero
+			return "*:*"
+		}
+		return parts[0] + ":" + parts[1] + ":*:*"
+	}
+}
+
+// MaskJWT keeps the header of a JWT and masks the payload and the signature,
+// values which are not JWTs are masked entirely.
+func MaskJWT(val string) string {
+	parts := strings.Split(val, ".")
+	if len(parts) != 3 {
+		return stringx.Mask(val, 0, 0, '*')
+	}
+	return parts[0] + ".***.***"
+}
+
+// MaskAPIKey keeps the first and the last 4 runes of an API key, which is enough to tell keys apart.
+func MaskAPIKey(val string) string {
+	// a key shorter than 16 runes is masked entirely, so that at least half of it is hidden
+	if len([]rune(val)) < 16 {
+		return stringx.Mask(val, 0, 0, '*')
+	}
+	return stringx.Mask(val, 4, 4, '*')
+}
@@ -0,0 +1,76 @@
+package zapx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMaskers(t *testing.T) {
+	testCases := []struct {
+		name   string
+		masker string
+		val    string
+		want   string
+	}{
+		{name: "手机号", masker: "phone", val: "13117127078", want: "131****7078"},
+		{name: "过短的手机号", masker: "phone", val: "12345", want: "*****"},
+		{name: "邮箱", masker: "email", val: "zkit@example.com", want: "z***@example.com"},
+		{name: "身份证号", masker: "idcard", val: "110101199003071234", want: "110***********1234"},
+		{name: "银行卡号", masker: "bankcard", val: "6222021234567891234", want: "6222***********1234"},
+		{name: "IPv4", masker: "ip", val: "192.168.1.10", want: "192.168.*.*"},
+		{name: "IPv6", masker: "ip", val: "2001:db8::1", want: "2001:db8:*:*"},
+		{name: "IPv6 回环地址", masker: "ip", val: "::1", want: "*:*"},
+		{name: "非 IP", masker: "ip", val: "host", want: "****"},
+		{name: "JWT", masker: "jwt", val: "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig", want: "eyJhbGciOiJIUzI1NiJ9.***.***"},
+		{name: "非 JWT", masker: "jwt", val: "token", want: "*****"},
+		{name: "API key", masker: "apikey", val: "sk-1234567890abcdef", want: "sk-1***********cdef"},
+		{name: "过短的 API key", masker: "apikey", val: "sk-123", want: "******"},
+		{name: "密码", masker: "password", val: "123", want: "******"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, builtinMaskers[tc.masker](tc.val))
+		})
+	}
+}
+
+func TestCustomCore_Maskers(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := zap.New(NewCustomCore(core,
+		WithMaskers("phone", "email", "bankcard"),
+		WithFieldMasker("mobile", "phone"),
+		WithMasker("name", func(val string) string { return "*" }),
+	))
+
+	fields := []zap.Field{
+		zap.Int("phone", 1),
+		zap.String("phone", "13117127078"),
+		zap.String("mobile", "1311"),
+		zap.String("email", "zkit@example.com"),
+		zap.String("bankcard", "6222021234567891234"),
+		zap.String("name", "Tom"),
+		zap.String("city", "Shanghai"),
+	}
+	l.Info("msg", fields...)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]any{
+		"phone":    "131****7078",
+		"mobile":   "****",
+		"email":    "z***@example.com",
+		"bankcard": "6222***********1234",
+		"name":     "*",
+		"city":     "Shanghai",
+	}, logs.All()[0].ContextMap())
+	// 调用方的字段不会被修改
+	assert.Equal(t, "13117127078", fields[1].String)
+
+	assert.PanicsWithValue(t, "zkit: 未知的脱敏规则 mobile", func() {
+		NewCustomCore(core, WithMaskers("mobile"))
+	})
+}
@@ -1,28 +1,129 @@
 package zapx
 
 import (
+	"fmt"
+
+	"github.com/ecloudclub/zkit/option"
 	"go.uber.org/zap/zapcore"
 )
 
+// CustomCore masks the sensitive fields before they are written by the wrapped core,
+// including the ones nested in objects, arrays, namespaces and values logged by zap.Any.
+// Only strings and byte strings are masked, numbers are written as they are even if their keys have maskers,
+// e.g. zap.Int64("phone", 13117127078), so log the sensitive numbers as strings.
 type CustomCore struct {
 	zapcore.Core
+	// maskers maps the keys of the fields to their maskers
+	maskers map[string]Masker
+}
+
+// WithMaskers masks the fields whose keys are the names of the built-in maskers:
+// phone, email, idcard, bankcard, ip, jwt, apikey and password.
+// It panics if a name is unknown, so that a typo does not leak sensitive data silently.
+func WithMaskers(names ...string) option.Option[CustomCore] {
+	return func(z *CustomCore) {
+		for _, name := range names {
+			z.maskers[name] = builtinMasker(name)
+		}
+	}
+}
+
+// WithFieldMasker masks the fields with key by the built-in masker of name, e.g. "mobile" by "phone".
+func WithFieldMasker(key, name string) option.Option[CustomCore] {
+	return func(z *CustomCore) {
+		z.maskers[key] = builtinMasker(name)
+	}
+}
+
+// WithMasker masks the fields with key by m.
+func WithMasker(key string, m Masker) option.Option[CustomCore] {
+	return func(z *CustomCore) {
+		z.maskers[key] = m
+	}
+}
+
+func builtinMasker(name string) Masker {
+	m, ok := builtinMaskers[name]
+	if !ok {
+		panic(fmt.Sprintf("zkit: 未知的脱敏规则 %s", name))
+	}
+	return m
+}
+
+// NewCustomCore wraps core to mask sensitive fields, which are the ones with the key phone by default.
+func NewCustomCore(core zapcore.Core, opts ...option.Option[CustomCore]) *CustomCore {
+	z := &CustomCore{
+		Core:    core,
+		maskers: make(map[string]Masker),
+	}
+	if len(opts) == 0 {
+		opts = append(opts, WithMaskers("phone"))
+	}
+	option.Apply(z, opts...)
+	return z
 }
 
-func NewCustomCore(core zapcore.Core) *CustomCore {
+func (z *CustomCore) With(fields []zapcore.Field) zapcore.Core {
 	return &CustomCore{
-		Core: core,
+		Core:    z.Core.With(z.mask(fields)),
+		maskers: z.maskers,
 	}
 }
 
 func (z *CustomCore) Write(en zapcore.Entry, fields []zapcore.Field) error {
+	return z.Core.Write(en, z.mask(fields))
+}
+
+// mask masks the sensitive fields, including the ones nested in objects, arrays and reflected values.
+// The fields are copied if any of them is masked, so that the slice of the caller is not modified.
+func (z *CustomCore) mask(fields []zapcore.Field) []zapcore.Field {
+	if len(z.maskers) == 0 {
+		return fields
+	}
+	masked, copied := fields, false
 	for i, fd := range fields {
-		if fd.Key == "phone" {
-			phone := fd.String
-			fields[i].String = phone[:3] + "****" + phone[7:]
+		m := z.maskers[fd.Key]
+		switch fd.Type {
+		case zapcore.StringType:
+			if m == nil {
+				continue
+			}
+			fd.String = m(fd.String)
+		case zapcore.ByteStringType:
+			if m == nil {
+				continue
+			}
+			fd.Interface = []byte(m(string(fd.Interface.([]byte))))
+		case zapcore.StringerType:
+			if m == nil {
+				continue
+			}
+			fd = zapcore.Field{Key: fd.Key, Type: zapcore.StringType, String: m(stringOf(fd.Interface))}
+		case zapcore.ObjectMarshalerType:
+			fd.Interface = maskedObject{obj: fd.Interface.(zapcore.ObjectMarshaler), maskers: z.maskers}
+		case zapcore.ArrayMarshalerType:
+			fd.Interface = maskedArray{arr: fd.Interface.(zapcore.ArrayMarshaler), maskers: z.maskers, masker: m}
+		case zapcore.ReflectType:
+			fd.Interface = maskReflected(fd.Interface, z.maskers, m)
+		default:
+			continue
 		}
+		if !copied {
+			masked, copied = append([]zapcore.Field(nil), fields...), true
+		}
+		masked[i] = fd
 	}
+	return masked
+}
 
-	return z.Core.Write(en, fields)
+// stringOf calls the String method of a fmt.Stringer like zap does, including recovering from nil pointers
+func stringOf(stringer any) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = "<nil>"
+		}
+	}()
+	return stringer.(fmt.Stringer).String()
 }
 
 func (z *CustomCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {